@@ -14,41 +14,76 @@ const (
 	AbsentSubValue
 )
 
+// Acceptable is a single element of an Accept-style header.
+//
+// Params holds the parameters that precede the "q" weight (for Accept, these
+// are the media type parameters), while Extensions holds any accept-extension
+// parameters that follow it.  Only Params take part in matching.
 type Acceptable struct {
-	Value    string
-	SubValue string
-	Params   map[string]string
-	Quality  Quality
+	Value      string
+	SubValue   string
+	Params     Params
+	Quality    Quality
+	Extensions Params
 }
 
 func (a Acceptable) Append(out []byte) []byte {
+	out, _ = a.AppendWithOptions(out, FormatOptions{})
+	return out
+}
+
+// AppendWithOptions is like Append, but applies opts.  It fails only if the
+// ObsText policy forbids a value that cannot be represented any other way.
+func (a Acceptable) AppendWithOptions(out []byte, opts FormatOptions) ([]byte, error) {
 	if a.Value == "" {
-		return out
+		return out, nil
 	}
 
-	out = appendToken(out, a.Value)
+	var err error
+	out, err = appendValueWith(out, a.Value, opts.ObsText)
+	if err != nil {
+		return out, err
+	}
 
 	if a.SubValue != "" {
 		out = append(out, '/')
-		out = appendToken(out, a.SubValue)
+		out, err = appendValueWith(out, a.SubValue, opts.ObsText)
+		if err != nil {
+			return out, err
+		}
 	}
 
-	if n := len(a.Params); n > 0 {
-		keys := paramKeys(a.Params)
-		for _, key := range keys {
-			out = append(out, ';')
-			out = appendToken(out, key)
-			out = append(out, '=')
-			out = appendToken(out, a.Params[key])
-		}
+	out, err = appendParamsWith(out, a.Params, opts.ObsText, true)
+	if err != nil {
+		return out, err
 	}
 
-	if a.Quality < 1000 {
+	if a.Quality < 1000 || len(a.Extensions) > 0 {
 		out = append(out, ";q="...)
 		out = a.Quality.Append(out)
 	}
 
-	return out
+	return appendParamsWith(out, a.Extensions, opts.ObsText, false)
+}
+
+func isIdentical(a, b Acceptable) bool {
+	return a.Value == b.Value &&
+		a.SubValue == b.SubValue &&
+		a.Quality == b.Quality &&
+		isIdenticalParams(a.Params, b.Params) &&
+		isIdenticalParams(a.Extensions, b.Extensions)
+}
+
+func isIdenticalParams(a, b Params) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (a Acceptable) String() string {
@@ -60,98 +95,32 @@ func (a Acceptable) MarshalText() ([]byte, error) {
 }
 
 func (a *Acceptable) Parse(input string, mode SubValueMode) error {
-	*a = Acceptable{}
-
-	input = consumeSpace(input)
-
-	value, rest, ok := consumeToken(input)
-	if !ok {
-		return fmt.Errorf("expect token, got %q", input)
-	}
-	input = rest
-
-	input = consumeSpace(input)
-
-	var subValue string
-	var hasSubValue bool
-
-	switch mode {
-	case OptionalSubValue:
-		hasSubValue = strings.HasPrefix(input, "/")
-
-	case RequiredSubValue:
-		hasSubValue = true
-		if !strings.HasPrefix(input, "/") {
-			return fmt.Errorf("expect '/', got %q", input)
-		}
+	return a.ParseWithOptions(input, ParseOptions{Mode: mode})
+}
 
-	case AbsentSubValue:
-		hasSubValue = false
+func (a *Acceptable) ParseWithOptions(input string, opts ParseOptions) error {
+	*a = Acceptable{}
 
-	default:
-		return fmt.Errorf("unknown mode %v", mode)
+	if err := checkMode(opts.Mode); err != nil {
+		return err
 	}
 
-	if hasSubValue {
-		input = input[1:]
-		input = consumeSpace(input)
-
-		subValue, rest, ok = consumeToken(input)
-		if !ok {
-			return fmt.Errorf("expect token, got %q", input)
-		}
-		input = rest
-
-		input = consumeSpace(input)
+	if err := opts.Limits.checkBytes(input); err != nil {
+		return err
 	}
 
-	var p map[string]string
-	var q Quality = 1000
-	for strings.HasPrefix(input, ";") {
-		input = input[1:]
-		input = consumeSpace(input)
-
-		var paramName string
-		paramName, rest, ok = consumeToken(input)
-		if !ok {
-			return fmt.Errorf("expect token, got %q", input)
-		}
-		input = rest
-
-		input = consumeSpace(input)
-		if !strings.HasPrefix(input, "=") {
-			return fmt.Errorf("expect '=', got %q", input)
-		}
-		input = input[1:]
-		input = consumeSpace(input)
-
-		var paramValue string
-		paramValue, rest, ok = consumeQuoted(input)
-		if !ok {
-			return fmt.Errorf("expect token or quoted string, got %q", input)
-		}
-		input = rest
-
-		input = consumeSpace(input)
-
-		paramName = strings.ToLower(paramName)
-		if paramName == "q" {
-			if err := q.Parse(paramValue); err != nil {
-				return fmt.Errorf("%w", err)
-			}
-		} else {
-			if p == nil {
-				p = make(map[string]string)
-			}
-			p[paramName] = paramValue
-		}
+	if err := a.parse(input, &opts); err != nil {
+		return err
 	}
+	return nil
+}
 
-	if input != "" {
-		return fmt.Errorf("expect ';', got %q", input)
+func (a *Acceptable) parse(input string, opts *ParseOptions) *ParseError {
+	e, err := scanElement(input, opts)
+	if err != nil {
+		return err
 	}
-
-	*a = Acceptable{value, subValue, p, q}
+	*a = e.Acceptable()
 	return nil
 }
 
@@ -176,9 +145,34 @@ func (a Acceptable) CompareTo(b Acceptable) int {
 	if cmp := a.Quality.CompareTo(b.Quality); cmp != 0 {
 		return cmp
 	}
+	if cmp := compareParams(a.Extensions, b.Extensions); cmp != 0 {
+		return cmp
+	}
 	return 0
 }
 
+func isValidWildcard(token string) bool {
+	return token == "*" || strings.IndexByte(token, '*') < 0
+}
+
+// isValidSubWildcard is like isValidWildcard, but also accepts a suffix
+// wildcard such as "*+json".
+func isValidSubWildcard(token string) bool {
+	if suffix, found := strings.CutPrefix(token, "*+"); found && suffix != "" {
+		return strings.IndexByte(suffix, '*') < 0
+	}
+	return isValidWildcard(token)
+}
+
+func checkMode(mode SubValueMode) error {
+	switch mode {
+	case OptionalSubValue, RequiredSubValue, AbsentSubValue:
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %v", mode)
+	}
+}
+
 var (
 	_ fmt.Stringer             = Acceptable{}
 	_ encoding.TextMarshaler   = Acceptable{}
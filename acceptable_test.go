@@ -1,14 +1,16 @@
 package acceptable
 
 import (
-	"fmt"
+	"errors"
 	"reflect"
 	"testing"
 )
 
 var (
-	paramsCharset = map[string]string{"charset": "utf-8"}
-	paramsWeird   = map[string]string{"weird": "some text with \" and \\"}
+	paramsCharset = Params{{Name: "charset", Value: "utf-8"}}
+	paramsWeird   = Params{{Name: "weird", Value: "some text with \" and \\"}}
+	paramsLevel   = Params{{Name: "level", Value: "1"}}
+	paramsFoo     = Params{{Name: "foo", Value: "bar"}}
 )
 
 func TestAcceptable_String(t *testing.T) {
@@ -21,69 +23,103 @@ func TestAcceptable_String(t *testing.T) {
 	testData := [...]testCase{
 		{
 			Name:   "Empty",
-			Input:  Acceptable{"", "", nil, 0},
+			Input:  Acceptable{"", "", nil, 0, nil},
 			Expect: "",
 		},
 		{
 			Name:   "ValueQ0",
-			Input:  Acceptable{"foo", "", nil, 0},
+			Input:  Acceptable{"foo", "", nil, 0, nil},
 			Expect: "foo;q=0",
 		},
 		{
 			Name:   "ValueQ1",
-			Input:  Acceptable{"foo", "", nil, 1000},
+			Input:  Acceptable{"foo", "", nil, 1000, nil},
 			Expect: "foo",
 		},
 		{
 			Name:   "StarQ0",
-			Input:  Acceptable{"*", "", nil, 0},
+			Input:  Acceptable{"*", "", nil, 0, nil},
 			Expect: "*;q=0",
 		},
 		{
 			Name:   "StarQ1",
-			Input:  Acceptable{"*", "", nil, 1000},
+			Input:  Acceptable{"*", "", nil, 1000, nil},
 			Expect: "*",
 		},
 		{
 			Name:   "ValueSubQ0",
-			Input:  Acceptable{"foo", "bar", nil, 0},
+			Input:  Acceptable{"foo", "bar", nil, 0, nil},
 			Expect: "foo/bar;q=0",
 		},
 		{
 			Name:   "ValueSubQ0",
-			Input:  Acceptable{"foo", "bar", nil, 1000},
+			Input:  Acceptable{"foo", "bar", nil, 1000, nil},
 			Expect: "foo/bar",
 		},
 		{
 			Name:   "ValueStarQ0",
-			Input:  Acceptable{"foo", "*", nil, 0},
+			Input:  Acceptable{"foo", "*", nil, 0, nil},
 			Expect: "foo/*;q=0",
 		},
 		{
 			Name:   "ValueStarQ0",
-			Input:  Acceptable{"foo", "*", nil, 1000},
+			Input:  Acceptable{"foo", "*", nil, 1000, nil},
 			Expect: "foo/*",
 		},
 		{
 			Name:   "StarStarQ0",
-			Input:  Acceptable{"*", "*", nil, 0},
+			Input:  Acceptable{"*", "*", nil, 0, nil},
 			Expect: "*/*;q=0",
 		},
 		{
 			Name:   "StarStarQ0",
-			Input:  Acceptable{"*", "*", nil, 1000},
+			Input:  Acceptable{"*", "*", nil, 1000, nil},
 			Expect: "*/*",
 		},
 		{
 			Name:   "Text-HTML-UTF8",
-			Input:  Acceptable{"text", "html", paramsCharset, 1000},
+			Input:  Acceptable{"text", "html", paramsCharset, 1000, nil},
 			Expect: "text/html;charset=utf-8",
 		},
 		{
 			Name:   "Weird",
-			Input:  Acceptable{"foo", "", paramsWeird, 1000},
+			Input:  Acceptable{"foo", "", paramsWeird, 1000, nil},
 			Expect: `foo;weird="some text with \" and \\"`,
 		},
+		{
+			Name:   "Extensions",
+			Input:  Acceptable{"text", "html", paramsLevel, 500, paramsFoo},
+			Expect: "text/html;level=1;q=0.5;foo=bar",
+		},
+		{
+			Name:   "ExtensionsQ1",
+			Input:  Acceptable{"text", "html", nil, 1000, paramsFoo},
+			Expect: "text/html;q=1;foo=bar",
+		},
+		{
+			Name: "OrderedParams",
+			Input: Acceptable{"multipart", "mixed", Params{
+				{Name: "boundary", Value: "x"},
+				{Name: "acme", Value: "2"},
+				{Name: "acme", Value: "1"},
+			}, 1000, nil},
+			Expect: "multipart/mixed;boundary=x;acme=2;acme=1",
+		},
+		{
+			Name:   "EmptyParam",
+			Input:  Acceptable{"text", "html", Params{{Name: "foo", Value: ""}}, 1000, nil},
+			Expect: `text/html;foo=""`,
+		},
+		{
+			Name:   "ParamNamedQ",
+			Input:  Acceptable{"text", "html", Params{{Name: "q", Value: "0.5"}}, 1000, nil},
+			Expect: "text/html;q*=UTF-8''0.5",
+		},
+		{
+			Name:   "ExtensionNamedQ",
+			Input:  Acceptable{"text", "html", nil, 1000, Params{{Name: "q", Value: "bar"}}},
+			Expect: "text/html;q=1;q=bar",
+		},
 	}
 
 	for _, row := range testData {
@@ -109,70 +145,114 @@ func TestAcceptable_Parse(t *testing.T) {
 		{
 			Name:   "GZip",
 			Input:  "gzip",
-			Expect: Acceptable{"gzip", "", nil, 1000},
+			Expect: Acceptable{"gzip", "", nil, 1000, nil},
 		},
 		{
 			Name:   "GZipQ1",
 			Input:  "gzip;q=1",
-			Expect: Acceptable{"gzip", "", nil, 1000},
+			Expect: Acceptable{"gzip", "", nil, 1000, nil},
 		},
 		{
 			Name:   "GZipQ0",
 			Input:  "gzip;q=0",
-			Expect: Acceptable{"gzip", "", nil, 0},
+			Expect: Acceptable{"gzip", "", nil, 0, nil},
 		},
 		{
 			Name:   "Text-HTML",
 			Input:  "text/html",
-			Expect: Acceptable{"text", "html", nil, 1000},
+			Expect: Acceptable{"text", "html", nil, 1000, nil},
 		},
 		{
 			Name:   "Text-HTML-UTF8",
 			Input:  "text/html;charset=utf-8",
-			Expect: Acceptable{"text", "html", paramsCharset, 1000},
+			Expect: Acceptable{"text", "html", paramsCharset, 1000, nil},
 		},
 		{
 			Name:   "Text-HTML-UTF8-q0.1",
 			Input:  "text/html;charset=utf-8;q=0.1",
-			Expect: Acceptable{"text", "html", paramsCharset, 100},
+			Expect: Acceptable{"text", "html", paramsCharset, 100, nil},
 		},
 		{
 			Name:   "Spaces",
 			Input:  " text / html ; charset = utf-8 ; q = 0.1 ",
-			Expect: Acceptable{"text", "html", paramsCharset, 100},
+			Expect: Acceptable{"text", "html", paramsCharset, 100, nil},
 		},
 		{
 			Name:   "Weird",
 			Input:  `foo;weird="some text with \" and \\"`,
-			Expect: Acceptable{"foo", "", paramsWeird, 1000},
+			Expect: Acceptable{"foo", "", paramsWeird, 1000, nil},
+		},
+		{
+			Name:   "Extensions",
+			Input:  "text/html;level=1;q=0.5;foo=bar",
+			Expect: Acceptable{"text", "html", paramsLevel, 500, paramsFoo},
+		},
+		{
+			Name:   "ExtensionsNamedQ",
+			Input:  "text/html;Q=1;q=bar",
+			Expect: Acceptable{"text", "html", nil, 1000, Params{{Name: "q", Value: "bar"}}},
+		},
+		{
+			Name:   "ExtendedParamNamedQ",
+			Input:  "text/html;q*=UTF-8''0.5",
+			Expect: Acceptable{"text", "html", Params{{Name: "q", Value: "0.5"}}, 1000, nil},
+		},
+		{
+			Name:   "EmptyParam",
+			Input:  `text/html;foo=""`,
+			Expect: Acceptable{"text", "html", Params{{Name: "foo", Value: ""}}, 1000, nil},
+		},
+		{
+			Name:  "OrderedParams",
+			Input: "multipart/mixed; Boundary=x; acme=2; acme=1",
+			Expect: Acceptable{"multipart", "mixed", Params{
+				{Name: "boundary", Value: "x"},
+				{Name: "acme", Value: "2"},
+				{Name: "acme", Value: "1"},
+			}, 1000, nil},
 		},
 
 		{
 			Name:  "FailEmpty",
 			Input: "",
-			Err:   fmt.Errorf("expect token, got \"\""),
+			Err:   &ParseError{"", 0, 0, ExpectToken, "", ErrExpectToken},
 		},
 		{
 			Name:  "FailComma",
 			Input: ",",
-			Err:   fmt.Errorf("expect token, got \",\""),
+			Err:   &ParseError{",", 0, 0, ExpectToken, ",", ErrExpectToken},
 		},
 		{
 			Name:  "FailSlash",
 			Input: "/",
-			Err:   fmt.Errorf("expect token, got \"/\""),
+			Err:   &ParseError{"/", 0, 0, ExpectToken, "/", ErrExpectToken},
 		},
 		{
 			Name:  "FailMissingSubValue",
 			Input: "gzip;q=1",
 			Mode:  RequiredSubValue,
-			Err:   fmt.Errorf("expect '/', got \";q=1\""),
+			Err:   &ParseError{"gzip;q=1", 4, 0, ExpectSlash, ";q=1", ErrExpectSlash},
 		},
 		{
 			Name:  "FailExtraSubValue",
 			Input: "text/html;q=1",
 			Mode:  AbsentSubValue,
-			Err:   fmt.Errorf("expect ';', got \"/html;q=1\""),
+			Err:   &ParseError{"text/html;q=1", 4, 0, ExpectSemi, "/html;q=1", ErrExpectSemi},
+		},
+		{
+			Name:  "FailMissingEqual",
+			Input: "text/html;level",
+			Err:   &ParseError{"text/html;level", 15, 0, ExpectEqual, "", ErrExpectEqual},
+		},
+		{
+			Name:  "FailUnterminatedQuote",
+			Input: `text/html;level="1`,
+			Err:   &ParseError{`text/html;level="1`, 16, 0, ExpectQuoted, `"1`, ErrExpectQuoted},
+		},
+		{
+			Name:  "FailQuality",
+			Input: "text/html; q=2",
+			Err:   &ParseError{"text/html; q=2", 13, 0, ExpectQuality, "2", ErrInvalidQuality},
 		},
 	}
 
@@ -189,3 +269,167 @@ func TestAcceptable_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseError(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Is     error
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Token",
+			Input:  "text/html, ;;, application/json",
+			Is:     ErrExpectToken,
+			Expect: `expect token, got ";;"`,
+		},
+		{
+			Name:   "Equal",
+			Input:  "text/html;charset",
+			Is:     ErrExpectEqual,
+			Expect: `expect '=', got ""`,
+		},
+		{
+			Name:   "Quality",
+			Input:  "text/html;q=high",
+			Is:     ErrInvalidQuality,
+			Expect: `invalid quality "high"`,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var list List
+			err := list.Parse(row.Input, OptionalSubValue)
+			if !errors.Is(err, row.Is) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Is, err)
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("wrong error type: %T", err)
+			}
+			if actual := pe.Error(); actual != row.Expect {
+				t.Errorf("wrong message:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestAcceptable_ParseStrict(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Mode   SubValueMode
+		Expect Acceptable
+		Err    error
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Conforming",
+			Input:  "text/html ; charset=utf-8 ;q=0.125",
+			Expect: Acceptable{"text", "html", paramsCharset, 125, nil},
+		},
+		{
+			Name:   "QualityTrailingPeriod",
+			Input:  "text/*;q=0.",
+			Expect: Acceptable{"text", "*", nil, 0, nil},
+		},
+		{
+			Name:   "StarStar",
+			Input:  "*/*",
+			Expect: Acceptable{"*", "*", nil, 1000, nil},
+		},
+		{
+			Name:   "StarAbsent",
+			Input:  "*;q=0",
+			Mode:   AbsentSubValue,
+			Expect: Acceptable{"*", "", nil, 0, nil},
+		},
+		{
+			Name:   "EmptyParam",
+			Input:  "text/html;;q=1",
+			Expect: Acceptable{"text", "html", nil, 1000, nil},
+		},
+		{
+			Name:   "TrailingEmptyParam",
+			Input:  "text/html;charset=utf-8;",
+			Expect: Acceptable{"text", "html", paramsCharset, 1000, nil},
+		},
+		{
+			Name:   "StarSuffix",
+			Input:  "application/*+json",
+			Expect: Acceptable{"application", "*+json", nil, 1000, nil},
+		},
+		{
+			Name:  "FailSpaceBeforeSlash",
+			Input: "text /html",
+			Err:   &ParseError{"text /html", 4, 0, ExpectNothing, " /html", ErrUnexpectedSpace},
+		},
+		{
+			Name:  "FailSpaceAfterSlash",
+			Input: "text/ html",
+			Err:   &ParseError{"text/ html", 5, 0, ExpectNothing, " html", ErrUnexpectedSpace},
+		},
+		{
+			Name:  "FailSpaceBeforeEqual",
+			Input: "text/html;charset =utf-8",
+			Err:   &ParseError{"text/html;charset =utf-8", 17, 0, ExpectNothing, " =utf-8", ErrUnexpectedSpace},
+		},
+		{
+			Name:  "FailSpaceAfterEqual",
+			Input: "text/html;charset= utf-8",
+			Err:   &ParseError{"text/html;charset= utf-8", 18, 0, ExpectNothing, " utf-8", ErrUnexpectedSpace},
+		},
+		{
+			Name:  "FailQualityPrecision",
+			Input: "text/html;q=0.12345",
+			Err:   &ParseError{"text/html;q=0.12345", 12, 0, ExpectQuality, "0.12345", ErrInvalidQuality},
+		},
+		{
+			Name:  "FailQualityLeadingPeriod",
+			Input: "text/html;q=.5",
+			Err:   &ParseError{"text/html;q=.5", 12, 0, ExpectQuality, ".5", ErrInvalidQuality},
+		},
+		{
+			Name:  "FailQualityQuoted",
+			Input: `text/html;q="0.5"`,
+			Err:   &ParseError{`text/html;q="0.5"`, 12, 0, ExpectQuality, "0.5", ErrInvalidQuality},
+		},
+		{
+			Name:  "FailGluedStarValue",
+			Input: "te*t/html",
+			Err:   &ParseError{"te*t/html", 0, 0, ExpectNothing, "te*t/html", ErrInvalidWildcard},
+		},
+		{
+			Name:  "FailGluedStarSubValue",
+			Input: "text/ht*",
+			Err:   &ParseError{"text/ht*", 0, 0, ExpectNothing, "text/ht*", ErrInvalidWildcard},
+		},
+		{
+			Name:  "FailStarSuffixGlued",
+			Input: "application/*+js*",
+			Err:   &ParseError{"application/*+js*", 0, 0, ExpectNothing, "application/*+js*", ErrInvalidWildcard},
+		},
+		{
+			Name:  "FailStarConcrete",
+			Input: "*/html",
+			Err:   &ParseError{"*/html", 0, 0, ExpectNothing, "*/html", ErrInvalidWildcard},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var actual Acceptable
+			err := actual.ParseWithOptions(row.Input, ParseOptions{Mode: row.Mode, Strict: true})
+			if !reflect.DeepEqual(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, actual)
+			}
+		})
+	}
+}
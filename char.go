@@ -17,7 +17,7 @@ func appendToken(out []byte, token string) []byte {
 		return append(out, '*')
 	}
 
-	if stringMatches(token, isToken) {
+	if token != "" && stringMatches(token, isToken) {
 		return append(out, token...)
 	}
 
@@ -87,12 +87,6 @@ func consumeQuoted(input string) (quoted string, rest string, ok bool) {
 		return consumeToken(input)
 	}
 
-	buf := gPool.Get().(*bytes.Buffer)
-	defer func() {
-		buf.Reset()
-		gPool.Put(buf)
-	}()
-
 	n := uint(len(input))
 	i := uint(1)
 	inEscape := false
@@ -105,25 +99,53 @@ func consumeQuoted(input string) (quoted string, rest string, ok bool) {
 			return
 
 		case inEscape:
-			buf.WriteByte(ch)
 			inEscape = false
 
 		case ch == '\\':
 			inEscape = true
 
 		case ch == '"':
-			quoted = buf.String()
+			quoted = input[:i]
 			rest = input[i:]
 			ok = true
 			return
-
-		default:
-			buf.WriteByte(ch)
 		}
 	}
 	return
 }
 
+func unquote(quoted string) string {
+	if quoted == "" || quoted[0] != '"' {
+		return quoted
+	}
+
+	quoted = quoted[1 : len(quoted)-1]
+	if strings.IndexByte(quoted, '\\') < 0 {
+		return quoted
+	}
+
+	buf := gPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		gPool.Put(buf)
+	}()
+
+	n := uint(len(quoted))
+	inEscape := false
+	for i := uint(0); i < n; i++ {
+		ch := quoted[i]
+		if !inEscape && ch == '\\' {
+			inEscape = true
+			continue
+		}
+		buf.WriteByte(ch)
+		inEscape = false
+	}
+	return buf.String()
+}
+
+func isObsText(ch byte) bool { return ch >= 0x80 }
+
 func isLWS(ch byte) bool    { return ch == ' ' || ch == '\t' }
 func isQuote(ch byte) bool  { return ch == '"' }
 func isComma(ch byte) bool  { return ch == ',' }
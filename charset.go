@@ -0,0 +1,64 @@
+package acceptable
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed charsets.txt
+var gCharsetData string
+
+var (
+	gCharsetOnce sync.Once
+	gCharsets    map[string]string
+)
+
+func loadCharsets() {
+	gCharsets = make(map[string]string, 256)
+
+	s := bufio.NewScanner(strings.NewReader(gCharsetData))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		for _, name := range fields {
+			gCharsets[strings.ToLower(name)] = fields[0]
+		}
+	}
+}
+
+// CanonicalCharset returns the IANA preferred MIME name for the named
+// character set, such as "ISO-8859-1" for "latin1".  Unknown names, and the
+// wildcard "*", are returned unchanged.
+func CanonicalCharset(name string) string {
+	gCharsetOnce.Do(loadCharsets)
+	if canonical, found := gCharsets[strings.ToLower(name)]; found {
+		return canonical
+	}
+	return name
+}
+
+// NegotiateCharset chooses among the available character sets for the given
+// Accept-Charset preferences, both parsed in AbsentSubValue mode.  Names are
+// canonicalized with CanonicalCharset before matching, so that "utf8" in a
+// request matches "UTF-8" on offer.
+//
+// Following RFC 9110, an empty preferences list accepts any charset, "*"
+// matches any charset not explicitly listed, and a charset not matched by
+// any preference is not acceptable.  Offers the client likes equally are
+// chosen in the order listed in available.  The returned element is the one
+// from available, with its original spelling.
+func NegotiateCharset(available, preferences List) (Acceptable, bool) {
+	offers := canonicalizeValues(available, CanonicalCharset)
+	prefs := canonicalizeValues(preferences, CanonicalCharset)
+
+	results := Negotiator{TieBreak: ServerOrderTieBreak}.NegotiateAll(offers, prefs)
+	if len(results) <= 0 || !results[0].Acceptable {
+		return Acceptable{}, false
+	}
+	return available[results[0].Index], true
+}
@@ -0,0 +1,112 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalCharset(t *testing.T) {
+	type testCase struct {
+		Input  string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{"utf-8", "UTF-8"},
+		{"utf8", "UTF-8"},
+		{"csUTF8", "UTF-8"},
+		{"latin1", "ISO-8859-1"},
+		{"ISO_8859-1:1987", "ISO-8859-1"},
+		{"iso-8859-1", "ISO-8859-1"},
+		{"SHIFT_JIS", "Shift_JIS"},
+		{"x-unknown", "x-unknown"},
+		{"*", "*"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Input, func(t *testing.T) {
+			if actual := CanonicalCharset(row.Input); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestNegotiateCharset(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Available   string
+		Preferences string
+		Expect      Acceptable
+		ExpectOK    bool
+	}
+
+	testData := [...]testCase{
+		{
+			Name:      "NoneAvailable",
+			Available: "",
+		},
+		{
+			Name:      "NonePreferred",
+			Available: "utf-8, iso-8859-1;q=0.5",
+			Expect:    Acceptable{"utf-8", "", nil, 1000, nil},
+			ExpectOK:  true,
+		},
+		{
+			Name:        "Alias",
+			Available:   "utf-8, ISO-8859-1",
+			Preferences: "latin1, utf8;q=0.5",
+			Expect:      Acceptable{"ISO-8859-1", "", nil, 1000, nil},
+			ExpectOK:    true,
+		},
+		{
+			Name:        "Unlisted",
+			Available:   "utf-8",
+			Preferences: "iso-8859-1",
+		},
+		{
+			Name:        "Wildcard",
+			Available:   "windows-1252, utf-8",
+			Preferences: "iso-8859-1, *;q=0.5",
+			Expect:      Acceptable{"windows-1252", "", nil, 1000, nil},
+			ExpectOK:    true,
+		},
+		{
+			Name:      "ServerOrderNonePreferred",
+			Available: "UTF-8, ISO-8859-1",
+			Expect:    Acceptable{"UTF-8", "", nil, 1000, nil},
+			ExpectOK:  true,
+		},
+		{
+			Name:        "ServerOrderWildcard",
+			Available:   "UTF-8, ISO-8859-1",
+			Preferences: "*",
+			Expect:      Acceptable{"UTF-8", "", nil, 1000, nil},
+			ExpectOK:    true,
+		},
+		{
+			Name:        "ExclusionBeatsWildcard",
+			Available:   "utf-8, us-ascii;q=0.1",
+			Preferences: "*, csUTF8;q=0",
+			Expect:      Acceptable{"us-ascii", "", nil, 100, nil},
+			ExpectOK:    true,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var available, preferences List
+			if err := available.Parse(row.Available, AbsentSubValue); err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Available, err)
+			}
+			if err := preferences.Parse(row.Preferences, AbsentSubValue); err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Preferences, err)
+			}
+
+			actual, ok := NegotiateCharset(available, preferences)
+			if ok != row.ExpectOK || !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v, %t\n\tactual: %#v, %t", row.Expect, row.ExpectOK, actual, ok)
+			}
+		})
+	}
+}
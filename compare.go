@@ -2,7 +2,6 @@ package acceptable
 
 import (
 	"math"
-	"sort"
 )
 
 func compareUints(a, b uint) int {
@@ -53,20 +52,14 @@ func compareValues(a, b string) int {
 	}
 }
 
-func compareParams(a, b map[string]string) int {
+func compareParams(a, b Params) int {
 	if cmp := compareUints(uint(len(a)), uint(len(b))); cmp != 0 {
 		return -cmp
 	}
 
-	aKeys, bKeys := paramKeys(a), paramKeys(b)
-	for i := range aKeys {
-		aKey, bKey := aKeys[i], bKeys[i]
-		if cmp := compareStrings(aKey, bKey); cmp != 0 {
-			return cmp
-		}
-
-		aValue, bValue := a[aKey], b[bKey]
-		if cmp := compareStrings(aValue, bValue); cmp != 0 {
+	a, b = a.sorted(), b.sorted()
+	for i := range a {
+		if cmp := compareParam(a[i], b[i]); cmp != 0 {
 			return cmp
 		}
 	}
@@ -74,15 +67,12 @@ func compareParams(a, b map[string]string) int {
 	return 0
 }
 
-func paramKeys(params map[string]string) []string {
-	if len(params) <= 0 {
-		return nil
+func compareParam(a, b Param) int {
+	if cmp := compareStrings(a.Name, b.Name); cmp != 0 {
+		return cmp
 	}
-
-	keys := make([]string, 0, len(params))
-	for key := range params {
-		keys = append(keys, key)
+	if cmp := compareParamValues(a.Name, a.Value, b.Value); cmp != 0 {
+		return cmp
 	}
-	sort.Strings(keys)
-	return keys
+	return compareFolded(a.Lang, b.Lang)
 }
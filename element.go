@@ -0,0 +1,411 @@
+package acceptable
+
+import (
+	"iter"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// Element is a lightweight view of a single parsed list element.
+//
+// All of its strings are substrings of the scanned input, so producing an
+// Element does not allocate.  Parameters are kept in their raw form and are
+// only decoded on request; Param avoids allocation unless the value is a
+// quoted string containing escapes.
+type Element struct {
+	Value    string
+	SubValue string
+	Quality  Quality
+	params   string
+	exts     string
+	text     string
+	sep      string
+}
+
+func (e Element) Param(name string) (string, bool) {
+	return lookupParam(e.params, name)
+}
+
+func (e Element) Extension(name string) (string, bool) {
+	return lookupParam(e.exts, name)
+}
+
+// Params iterates over the parameters, with names lowercased and extended
+// values decoded, as in Acceptable.Params.
+func (e Element) Params() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		walkDecodedParams(e.params, yield)
+	}
+}
+
+func (e Element) Extensions() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		walkDecodedParams(e.exts, yield)
+	}
+}
+
+// Text returns the original text of the element, without surrounding
+// whitespace.
+func (e Element) Text() string {
+	return e.text
+}
+
+// Acceptable converts e to an Acceptable.  Its strings are copied, so that
+// the result does not alias the input e was scanned from, even if that was
+// a byte slice passed to ElementsBytes.
+func (e Element) Acceptable() Acceptable {
+	return Acceptable{
+		Value:      strings.Clone(e.Value),
+		SubValue:   strings.Clone(e.SubValue),
+		Params:     collectParams(e.params),
+		Quality:    e.Quality,
+		Extensions: collectParams(e.exts),
+	}
+}
+
+// Elements returns an iterator over the elements of an Accept-style header.
+//
+// Each malformed element is yielded as a non-nil error.  Iteration stops
+// after the first error unless opts.Lenient is set, in which case it stops
+// only when a header-wide limit is exceeded.
+func Elements(input string, opts ParseOptions) iter.Seq2[Element, error] {
+	return func(yield func(Element, error) bool) {
+		scanList(input, &opts, yield)
+	}
+}
+
+// ElementsBytes is like Elements, but scans a byte slice without copying it.
+// The yielded Elements alias input, which must not be modified while they
+// are in use.
+func ElementsBytes(input []byte, opts ParseOptions) iter.Seq2[Element, error] {
+	return Elements(unsafe.String(unsafe.SliceData(input), len(input)), opts)
+}
+
+func scanList(input string, opts *ParseOptions, yield func(Element, error) bool) {
+	if err := checkMode(opts.Mode); err != nil {
+		yield(Element{}, err)
+		return
+	}
+
+	if err := opts.Limits.checkBytes(input); err != nil {
+		yield(Element{}, err)
+		return
+	}
+
+	type pstate uint
+	const (
+		rootState pstate = iota
+		quoteState
+		escapeState
+	)
+
+	var index, prevEnd int
+	scanOne := func(start, end uint) bool {
+		str := consumeSpace(input[start:end])
+		if str == "" {
+			return true
+		}
+
+		text := strings.TrimRight(str, " \t")
+		textStart := int(end) - len(str)
+		sep := input[prevEnd:textStart]
+		prevEnd = textStart + len(text)
+
+		if max := opts.Limits.MaxElements; max > 0 && index >= max {
+			err := newSentinelError(input, textStart, ErrTooManyElements, "")
+			err.Index = index
+			yield(Element{}, err)
+			return false
+		}
+
+		e, err := scanElement(str, opts)
+		if err != nil {
+			err.Input = input
+			err.Offset += textStart
+			err.Index = index
+			index++
+			return yield(Element{}, err) && opts.Lenient
+		}
+		e.text = text
+		e.sep = sep
+		index++
+		return yield(e, nil)
+	}
+
+	state := rootState
+	limit := uint(len(input))
+	start := uint(0)
+	for i := uint(0); i < limit; i++ {
+		ch := input[i]
+		switch {
+		case state == escapeState:
+			state = quoteState
+		case state == quoteState && ch == '\\':
+			state = escapeState
+		case state == quoteState && ch == '"':
+			state = rootState
+		case state == rootState && ch == '"':
+			state = quoteState
+		case state == rootState && ch == ',':
+			if !scanOne(start, i) {
+				return
+			}
+			start = i + 1
+		}
+	}
+
+	scanOne(start, limit)
+}
+
+func scanElement(input string, opts *ParseOptions) (Element, *ParseError) {
+	var e Element
+
+	orig := input
+	fail := func(expect Expect, rest string) *ParseError {
+		return newParseError(orig, len(orig)-len(rest), expect, rest)
+	}
+	reject := func(err error, rest string) *ParseError {
+		return newSentinelError(orig, len(orig)-len(rest), err, rest)
+	}
+	checkLength := func(token string, start string) *ParseError {
+		if max := opts.Limits.MaxTokenLength; max > 0 && len(token) > max {
+			return newSentinelError(orig, len(orig)-len(start), ErrTokenTooLong, start[:len(token)])
+		}
+		return nil
+	}
+	noSpace := func(rest string) *ParseError {
+		if opts.Strict && rest != "" && isLWS(rest[0]) {
+			return reject(ErrUnexpectedSpace, rest)
+		}
+		return nil
+	}
+
+	input = consumeSpace(input)
+
+	valueStart := input
+	value, rest, ok := consumeToken(input)
+	if !ok {
+		return e, fail(ExpectToken, input)
+	}
+	if err := checkLength(value, input); err != nil {
+		return e, err
+	}
+	input = rest
+
+	if opts.Strict && !isValidWildcard(value) {
+		return e, reject(ErrInvalidWildcard, valueStart)
+	}
+
+	if strings.HasPrefix(consumeSpace(input), "/") {
+		if err := noSpace(input); err != nil {
+			return e, err
+		}
+	}
+	input = consumeSpace(input)
+
+	var subValue string
+	var hasSubValue bool
+
+	switch opts.Mode {
+	case OptionalSubValue:
+		hasSubValue = strings.HasPrefix(input, "/")
+
+	case RequiredSubValue:
+		hasSubValue = true
+		if !strings.HasPrefix(input, "/") {
+			return e, fail(ExpectSlash, input)
+		}
+
+	case AbsentSubValue:
+		hasSubValue = false
+	}
+
+	if hasSubValue {
+		input = input[1:]
+		if err := noSpace(input); err != nil {
+			return e, err
+		}
+		input = consumeSpace(input)
+
+		subValue, rest, ok = consumeToken(input)
+		if !ok {
+			return e, fail(ExpectToken, input)
+		}
+		if err := checkLength(subValue, input); err != nil {
+			return e, err
+		}
+		input = rest
+
+		if opts.Strict && !isValidSubWildcard(subValue) {
+			return e, reject(ErrInvalidWildcard, valueStart)
+		}
+		if opts.Strict && value == "*" && subValue != "*" {
+			return e, reject(ErrInvalidWildcard, valueStart)
+		}
+
+		input = consumeSpace(input)
+	}
+
+	var q Quality = 1000
+	var hasQ bool
+	var numParams int
+	params := input
+	var exts string
+	for strings.HasPrefix(input, ";") {
+		paramStart := input
+		input = input[1:]
+		input = consumeSpace(input)
+
+		// Empty parameters are permitted (RFC 9110 section 5.6.6).
+		if input == "" || input[0] == ';' {
+			continue
+		}
+
+		if max := opts.Limits.MaxParams; max > 0 && numParams >= max {
+			return e, reject(ErrTooManyParams, paramStart)
+		}
+		numParams++
+
+		var paramName string
+		paramName, rest, ok = consumeToken(input)
+		if !ok {
+			return e, fail(ExpectToken, input)
+		}
+		if err := checkLength(paramName, input); err != nil {
+			return e, err
+		}
+		input = rest
+
+		if err := noSpace(input); err != nil {
+			return e, err
+		}
+		input = consumeSpace(input)
+		if !strings.HasPrefix(input, "=") {
+			return e, fail(ExpectEqual, input)
+		}
+		input = input[1:]
+		if err := noSpace(input); err != nil {
+			return e, err
+		}
+		input = consumeSpace(input)
+
+		valueStart := input
+		var rawValue string
+		rawValue, rest, ok = consumeQuoted(input)
+		if !ok {
+			return e, fail(ExpectQuoted, input)
+		}
+		if err := checkLength(rawValue, input); err != nil {
+			return e, err
+		}
+		input = rest
+
+		if i, err := checkObsText(rawValue, opts.ObsText); err != nil {
+			return e, newSentinelError(orig, len(orig)-len(valueStart)+i, err, valueStart[i:])
+		}
+
+		if strings.HasSuffix(paramName, "*") {
+			if !isValidExtValue(rawValue) {
+				return e, fail(ExpectExtValue, valueStart)
+			}
+			if opts.ObsText == ObsTextUTF8 || opts.ObsText == ObsTextReject {
+				if decoded, _, _ := decodeExtValue(rawValue); !utf8.ValidString(decoded) {
+					return e, newSentinelError(orig, len(orig)-len(valueStart), ErrInvalidUTF8, valueStart)
+				}
+			}
+		}
+
+		input = consumeSpace(input)
+
+		if !hasQ && (paramName == "q" || paramName == "Q") {
+			isQuoted := strings.HasPrefix(rawValue, "\"")
+			paramValue := unquote(rawValue)
+			if err := q.parse(paramValue, opts.Strict); err != nil || (opts.Strict && isQuoted) {
+				err := fail(ExpectQuality, valueStart)
+				err.Found = paramValue
+				return e, err
+			}
+			hasQ = true
+			params = params[:len(params)-len(paramStart)]
+			exts = input
+		}
+	}
+
+	if input != "" {
+		return e, fail(ExpectSemi, input)
+	}
+
+	if hasQ {
+		exts = exts[:len(exts)-len(input)]
+	} else {
+		params = params[:len(params)-len(input)]
+	}
+
+	e = Element{value, subValue, q, params, exts, "", ""}
+	return e, nil
+}
+
+func lookupParam(raw string, name string) (value string, found bool) {
+	walkParams(raw, func(n, v string) bool {
+		base, isExt := strings.CutSuffix(n, "*")
+		if !strings.EqualFold(base, name) {
+			return true
+		}
+		if isExt {
+			v, _, _ = decodeExtValue(v)
+		}
+		value, found = v, true
+		return false
+	})
+	return
+}
+
+func collectParams(raw string) Params {
+	var params Params
+	walkParams(raw, func(name, value string) bool {
+		p := decodeParam(name, value)
+		p.Name = strings.Clone(p.Name)
+		p.Value = strings.Clone(p.Value)
+		p.Lang = strings.Clone(p.Lang)
+		params = append(params, p)
+		return true
+	})
+	return params
+}
+
+// walkDecodedParams is like walkParams, but lowercases names and decodes
+// extended values, as Acceptable.Params does.
+func walkDecodedParams(raw string, yield func(name, value string) bool) {
+	walkParams(raw, func(name, value string) bool {
+		p := decodeParam(name, value)
+		return yield(p.Name, p.Value)
+	})
+}
+
+func decodeParam(name, value string) Param {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, "*") {
+		return Param{Name: name, Value: value}
+	}
+	value, lang, _ := decodeExtValue(value)
+	return Param{Name: name[:len(name)-1], Value: value, Lang: lang}
+}
+
+func walkParams(raw string, yield func(name, value string) bool) {
+	input := consumeSpace(raw)
+	for strings.HasPrefix(input, ";") {
+		input = consumeSpace(input[1:])
+		if input == "" || input[0] == ';' {
+			continue
+		}
+		name, rest, _ := consumeToken(input)
+		input = consumeSpace(rest)
+		input = consumeSpace(input[1:])
+		value, rest, _ := consumeQuoted(input)
+		input = consumeSpace(rest)
+		if !yield(name, unquote(value)) {
+			return
+		}
+	}
+}
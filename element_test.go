@@ -0,0 +1,130 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElements(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   string
+		Options ParseOptions
+		Expect  List
+		Errs    int
+	}
+
+	testData := [...]testCase{
+		{
+			Name:  "Empty",
+			Input: " , ",
+		},
+		{
+			Name:  "Accept",
+			Input: `text/html;level="1";q=0.5;foo=bar, application/json;charset=utf-8`,
+			Expect: List{
+				{"text", "html", paramsLevel, 500, paramsFoo},
+				{"application", "json", paramsCharset, 1000, nil},
+			},
+		},
+		{
+			Name:  "StopAtError",
+			Input: "text/html, ;;, application/json",
+			Expect: List{
+				{"text", "html", nil, 1000, nil},
+			},
+			Errs: 1,
+		},
+		{
+			Name:    "Lenient",
+			Input:   "text/html, ;;, application/json, /",
+			Options: ParseOptions{Lenient: true},
+			Expect: List{
+				{"text", "html", nil, 1000, nil},
+				{"application", "json", nil, 1000, nil},
+			},
+			Errs: 2,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			input := []byte(row.Input)
+			var actual List
+			var errs int
+			for e, err := range ElementsBytes(input, row.Options) {
+				if err != nil {
+					errs++
+					continue
+				}
+				actual = append(actual, e.Acceptable())
+			}
+
+			// The results must not alias input.
+			for i := range input {
+				input[i] = 'x'
+			}
+			if errs != row.Errs {
+				t.Errorf("wrong error count:\n\texpect: %d\n\tactual: %d", row.Errs, errs)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestElement_Param(t *testing.T) {
+	const input = `text/html;Charset=utf-8;title="a \"b\"";q=0.5;foo=bar`
+
+	for e, err := range Elements(input, ParseOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value, ok := e.Param("charset"); !ok || value != "utf-8" {
+			t.Errorf("Param(charset): wrong result: %q, %t", value, ok)
+		}
+		if value, ok := e.Param("title"); !ok || value != `a "b"` {
+			t.Errorf("Param(title): wrong result: %q, %t", value, ok)
+		}
+		if _, ok := e.Param("foo"); ok {
+			t.Errorf("Param(foo): extension reported as parameter")
+		}
+		if value, ok := e.Extension("foo"); !ok || value != "bar" {
+			t.Errorf("Extension(foo): wrong result: %q, %t", value, ok)
+		}
+
+		var names []string
+		for name := range e.Params() {
+			names = append(names, name)
+		}
+		if expect := []string{"charset", "title"}; !reflect.DeepEqual(names, expect) {
+			t.Errorf("Params: wrong result:\n\texpect: %q\n\tactual: %q", expect, names)
+		}
+	}
+}
+
+func TestElements_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful under the race detector")
+	}
+
+	input := []byte("text/html, application/xhtml+xml, application/xml;q=0.9, image/webp, */*;q=0.8")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for e, err := range ElementsBytes(input, ParseOptions{}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Value == "application" && e.SubValue == "json" {
+				break
+			}
+			if _, ok := e.Param("charset"); ok {
+				break
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("wrong allocation count:\n\texpect: 0\n\tactual: %v", allocs)
+	}
+}
@@ -0,0 +1,81 @@
+package acceptable
+
+import (
+	"strings"
+)
+
+// kImplicitQuality is the weight given to the implicit "identity" offer and
+// preference: acceptable, but less preferred than anything listed.
+const kImplicitQuality Quality = 1
+
+var gEncodingAliases = map[string]string{
+	"x-gzip":     "gzip",
+	"x-compress": "compress",
+}
+
+// CanonicalEncoding returns the canonical name of a content coding: lower
+// case, with the aliases "x-gzip" and "x-compress" replaced by "gzip" and
+// "compress" (RFC 9110 section 8.4.1).
+func CanonicalEncoding(name string) string {
+	name = strings.ToLower(name)
+	if canonical, found := gEncodingAliases[name]; found {
+		return canonical
+	}
+	return name
+}
+
+// NegotiateEncoding chooses among the available content codings for the
+// client's Accept-Encoding field, following RFC 9110 section 12.5.3:
+//
+//   - If the field is absent, any coding is acceptable.
+//   - If the field is present but empty, only "identity" is acceptable.
+//   - Otherwise, "identity" is acceptable unless excluded by "identity;q=0",
+//     or by "*;q=0" without an explicit "identity" entry.
+//
+// The "identity" coding is always offered, at the lowest possible weight, if
+// available does not list it; if chosen, it is returned with MaxQuality.
+// Codings the client likes equally are chosen in the order listed in
+// available.  Explicitly listing "identity;q=0" in
+// available makes the server unwilling to send unencoded content.
+//
+// If no coding is acceptable, NegotiateEncoding returns false; the server
+// should respond with 406 Not Acceptable.
+func NegotiateEncoding(available List, field Field) (Acceptable, bool) {
+	offers := canonicalizeValues(available, CanonicalEncoding)
+	if !hasValue(offers, "identity") {
+		offers = append(offers, Acceptable{Value: "identity", Quality: kImplicitQuality})
+	}
+
+	var prefs List
+	switch {
+	case !field.Present:
+		// Any coding is acceptable.
+
+	case len(field.List) <= 0:
+		prefs = List{{Value: "identity", Quality: MaxQuality}}
+
+	default:
+		prefs = canonicalizeValues(field.List, CanonicalEncoding)
+		if !hasValue(prefs, "identity") && !hasValue(prefs, "*") {
+			prefs = append(prefs, Acceptable{Value: "identity", Quality: kImplicitQuality})
+		}
+	}
+
+	results := Negotiator{TieBreak: ServerOrderTieBreak}.NegotiateAll(offers, prefs)
+	if len(results) <= 0 || !results[0].Acceptable {
+		return Acceptable{}, false
+	}
+	if i := results[0].Index; i < len(available) {
+		return available[i], true
+	}
+	return Acceptable{Value: "identity", Quality: MaxQuality}, true
+}
+
+func hasValue(list List, value string) bool {
+	for _, a := range list {
+		if a.Value == value {
+			return true
+		}
+	}
+	return false
+}
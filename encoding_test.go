@@ -0,0 +1,134 @@
+package acceptable
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Available string
+		Header    []string
+		Expect    string
+		ExpectOK  bool
+	}
+
+	testData := [...]testCase{
+		{
+			Name:      "Absent",
+			Available: "gzip, br",
+			Expect:    "gzip",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "Empty",
+			Available: "gzip, br",
+			Header:    []string{""},
+			Expect:    "identity",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "EmptyIdentityRefused",
+			Available: "gzip, identity;q=0",
+			Header:    []string{""},
+		},
+		{
+			Name:      "ServerOrder",
+			Available: "zstd, gzip",
+			Header:    []string{"gzip, zstd"},
+			Expect:    "zstd",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "Preferred",
+			Available: "gzip, br",
+			Header:    []string{"gzip, br;q=0.5"},
+			Expect:    "gzip",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "Alias",
+			Available: "x-gzip, deflate",
+			Header:    []string{"gzip;q=1, deflate;q=0.5"},
+			Expect:    "x-gzip",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "AliasInHeader",
+			Available: "compress, deflate;q=0.5",
+			Header:    []string{"x-compress"},
+			Expect:    "compress",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "ImplicitIdentity",
+			Available: "br",
+			Header:    []string{"gzip"},
+			Expect:    "identity",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "IdentityExcluded",
+			Available: "br",
+			Header:    []string{"gzip, identity;q=0"},
+		},
+		{
+			Name:      "WildcardExcluded",
+			Available: "br",
+			Header:    []string{"gzip, *;q=0"},
+		},
+		{
+			Name:      "WildcardExcludedIdentityListed",
+			Available: "br",
+			Header:    []string{"identity;q=0.5, *;q=0"},
+			Expect:    "identity",
+			ExpectOK:  true,
+		},
+		{
+			// RFC 9110 section 12.5.3
+			Name:      "RFCExample",
+			Available: "br, gzip;q=0.5",
+			Header:    []string{"gzip;q=1.0, identity; q=0.5, *;q=0"},
+			Expect:    "gzip;q=0.5",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "RFCExampleIdentity",
+			Available: "br, deflate",
+			Header:    []string{"gzip;q=1.0, identity; q=0.5, *;q=0"},
+			Expect:    "identity",
+			ExpectOK:  true,
+		},
+		{
+			Name:      "ExclusionBeatsWildcard",
+			Available: "br, gzip;q=0.5",
+			Header:    []string{"*, br;q=0"},
+			Expect:    "gzip;q=0.5",
+			ExpectOK:  true,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var available List
+			if err := available.Parse(row.Available, AbsentSubValue); err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Available, err)
+			}
+
+			h := make(http.Header)
+			for _, line := range row.Header {
+				h.Add("Accept-Encoding", line)
+			}
+			field, err := ParseHeader(h, "Accept-Encoding", ParseOptions{Mode: AbsentSubValue})
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Header, err)
+			}
+
+			actual, ok := NegotiateEncoding(available, field)
+			if ok != row.ExpectOK || actual.String() != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q, %t\n\tactual: %q, %t", row.Expect, row.ExpectOK, actual.String(), ok)
+			}
+		})
+	}
+}
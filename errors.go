@@ -0,0 +1,127 @@
+package acceptable
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Expect uint
+
+const (
+	ExpectNothing Expect = iota
+	ExpectToken
+	ExpectSlash
+	ExpectEqual
+	ExpectQuoted
+	ExpectSemi
+	ExpectQuality
+	ExpectExtValue
+)
+
+var gExpectNames = [...]string{
+	"nothing",
+	"token",
+	"'/'",
+	"'='",
+	"token or quoted string",
+	"';'",
+	"quality",
+	"extended value",
+}
+
+func (e Expect) String() string {
+	if e < Expect(len(gExpectNames)) {
+		return gExpectNames[e]
+	}
+	return fmt.Sprintf("Expect(%d)", uint(e))
+}
+
+func (e Expect) Err() error {
+	switch e {
+	case ExpectToken:
+		return ErrExpectToken
+	case ExpectSlash:
+		return ErrExpectSlash
+	case ExpectEqual:
+		return ErrExpectEqual
+	case ExpectQuoted:
+		return ErrExpectQuoted
+	case ExpectSemi:
+		return ErrExpectSemi
+	case ExpectQuality:
+		return ErrInvalidQuality
+	case ExpectExtValue:
+		return ErrExpectExtValue
+	default:
+		return nil
+	}
+}
+
+var (
+	ErrExpectToken    = errors.New("expect token")
+	ErrExpectSlash    = errors.New("expect '/'")
+	ErrExpectEqual    = errors.New("expect '='")
+	ErrExpectQuoted   = errors.New("expect token or quoted string")
+	ErrExpectSemi     = errors.New("expect ';'")
+	ErrInvalidQuality = errors.New("invalid quality")
+	ErrExpectExtValue = errors.New("expect extended value")
+
+	ErrUnexpectedSpace = errors.New("unexpected whitespace")
+	ErrInvalidWildcard = errors.New("invalid wildcard")
+
+	ErrHeaderTooLong   = errors.New("header too long")
+	ErrTooManyElements = errors.New("too many elements")
+	ErrTooManyParams   = errors.New("too many parameters")
+	ErrTokenTooLong    = errors.New("token too long")
+
+	ErrObsText     = errors.New("unexpected obs-text")
+	ErrInvalidUTF8 = errors.New("invalid UTF-8")
+)
+
+// ParseError describes a syntax error in a header value.
+//
+// Input is the complete text that was being parsed, Offset is the byte offset
+// into Input at which the problem was detected, and Index is the position of
+// the failing element within a list (always 0 for a lone Acceptable).  Found
+// holds the offending text: usually the unparsed remainder of the element
+// starting at Offset, or the rejected value for ExpectQuality.
+type ParseError struct {
+	Input  string
+	Offset int
+	Index  int
+	Expect Expect
+	Found  string
+	Err    error
+}
+
+func (err *ParseError) Error() string {
+	if err.Expect == ExpectQuality {
+		return fmt.Sprintf("invalid quality %q", err.Found)
+	}
+	if err.Expect == ExpectNothing && err.Found == "" {
+		return fmt.Sprintf("%v at offset %d", err.Err, err.Offset)
+	}
+	return fmt.Sprintf("%v, got %q", err.Err, err.Found)
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+func newParseError(input string, offset int, expect Expect, found string) *ParseError {
+	return &ParseError{
+		Input:  input,
+		Offset: offset,
+		Expect: expect,
+		Found:  found,
+		Err:    expect.Err(),
+	}
+}
+
+func newSentinelError(input string, offset int, err error, found string) *ParseError {
+	pe := newParseError(input, offset, ExpectNothing, found)
+	pe.Err = err
+	return pe
+}
+
+var _ error = (*ParseError)(nil)
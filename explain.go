@@ -0,0 +1,112 @@
+package acceptable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Reason records the outcome of trying one client preference against one
+// available offer.
+type Reason uint
+
+const (
+	ReasonMatch Reason = iota
+	ReasonValueMismatch
+	ReasonSubValueMismatch
+	ReasonParamMismatch
+	ReasonZeroQuality
+	ReasonSuffixMatch
+)
+
+var gReasonNames = [...]string{
+	"match",
+	"value mismatch",
+	"subvalue mismatch",
+	"param mismatch",
+	"zero quality",
+	"suffix match",
+}
+
+func (r Reason) String() string {
+	if r < Reason(len(gReasonNames)) {
+		return gReasonNames[r]
+	}
+	return fmt.Sprintf("Reason(%d)", uint(r))
+}
+
+func (r Reason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Attempt records one client preference tried against the offer at Index.
+type Attempt struct {
+	Index           int
+	Preference      Acceptable
+	PreferenceIndex int
+	Reason          Reason
+}
+
+// Trace is the ranking of one offer together with every preference that was
+// tried against it, in the order tried.
+type Trace struct {
+	Result
+	Attempts []Attempt
+}
+
+// Explanation records everything Negotiate considered, for debugging.
+// Offers are listed in ranked order, best first.
+type Explanation struct {
+	Available   List
+	Preferences List
+	Offers      []Trace
+}
+
+// Explain performs the same negotiation as NegotiateAll, but also records
+// why each preference did or did not match each offer.
+func Explain(available, preferences List) Explanation {
+	return Negotiator{}.Explain(available, preferences)
+}
+
+// Explain is like the package-level Explain, but uses n's options.
+func (n Negotiator) Explain(available, preferences List) Explanation {
+	attempts := make([][]Attempt, len(available))
+	results := n.negotiate(context.Background(), available, preferences, func(at Attempt) {
+		attempts[at.Index] = append(attempts[at.Index], at)
+	})
+
+	x := Explanation{
+		Available:   available,
+		Preferences: preferences,
+		Offers:      make([]Trace, len(results)),
+	}
+	for i, r := range results {
+		x.Offers[i] = Trace{Result: r, Attempts: attempts[r.Index]}
+	}
+	return x
+}
+
+// Best returns the best acceptable offer, if any.
+func (x Explanation) Best() (Acceptable, bool) {
+	results := make([]Result, len(x.Offers))
+	for i, trace := range x.Offers {
+		results[i] = trace.Result
+	}
+	return bestOffer(results)
+}
+
+func (x Explanation) String() string {
+	var sb strings.Builder
+	for rank, trace := range x.Offers {
+		fmt.Fprintf(&sb, "%d. %v (offer %d): ", rank+1, trace.Offer, trace.Index)
+		if trace.Acceptable {
+			fmt.Fprintf(&sb, "score %.6g\n", trace.Score)
+		} else {
+			sb.WriteString("not acceptable\n")
+		}
+		for _, at := range trace.Attempts {
+			fmt.Fprintf(&sb, "\t%v (preference %d): %v\n", at.Preference, at.PreferenceIndex, at.Reason)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,96 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	html := Acceptable{"text", "html", nil, 1000, nil}
+	xml := Acceptable{"application", "xml", nil, 1000, nil}
+	level := Acceptable{"text", "html", paramsLevel, 1000, nil}
+
+	available := List{html, xml}
+	preferences := List{
+		{"text", "html", paramsLevel, 1000, nil},
+		{"text", "*", nil, 500, nil},
+		{"application", "xml", nil, 0, nil},
+	}
+
+	x := Explain(available, preferences)
+
+	expect := []Trace{
+		{
+			Result: Result{
+				Offer:           html,
+				Preference:      preferences[1],
+				Index:           0,
+				PreferenceIndex: 1,
+				Score:           0.5,
+				Matched:         true,
+				Acceptable:      true,
+			},
+			Attempts: []Attempt{
+				{0, level, 0, ReasonParamMismatch},
+				{0, preferences[2], 2, ReasonValueMismatch},
+				{0, preferences[1], 1, ReasonMatch},
+			},
+		},
+		{
+			Result: Result{
+				Offer:           xml,
+				Preference:      preferences[2],
+				Index:           1,
+				PreferenceIndex: 2,
+				Matched:         true,
+			},
+			Attempts: []Attempt{
+				{1, level, 0, ReasonValueMismatch},
+				{1, preferences[2], 2, ReasonZeroQuality},
+			},
+		},
+	}
+	if !reflect.DeepEqual(x.Offers, expect) {
+		t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", expect, x.Offers)
+	}
+
+	if best, ok := x.Best(); !ok || !reflect.DeepEqual(best, html) {
+		t.Errorf("wrong best:\n\texpect: %v, true\n\tactual: %v, %t", html, best, ok)
+	}
+
+	const expectText = "1. text/html (offer 0): score 0.5\n" +
+		"\ttext/html;level=1 (preference 0): param mismatch\n" +
+		"\tapplication/xml;q=0 (preference 2): value mismatch\n" +
+		"\ttext/*;q=0.5 (preference 1): match\n" +
+		"2. application/xml (offer 1): not acceptable\n" +
+		"\ttext/html;level=1 (preference 0): value mismatch\n" +
+		"\tapplication/xml;q=0 (preference 2): zero quality\n"
+	if actual := x.String(); actual != expectText {
+		t.Errorf("wrong text:\n\texpect: %q\n\tactual: %q", expectText, actual)
+	}
+}
+
+func TestReason_String(t *testing.T) {
+	type testCase struct {
+		Input  Reason
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{ReasonMatch, "match"},
+		{ReasonValueMismatch, "value mismatch"},
+		{ReasonSubValueMismatch, "subvalue mismatch"},
+		{ReasonParamMismatch, "param mismatch"},
+		{ReasonZeroQuality, "zero quality"},
+		{ReasonSuffixMatch, "suffix match"},
+		{Reason(99), "Reason(99)"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Expect, func(t *testing.T) {
+			if actual := row.Input.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package acceptable
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const kHexDigits = "0123456789ABCDEF"
+
+// splitExtValue splits an RFC 8187 ext-value of the form
+// charset'language'value-chars into its three parts.
+func splitExtValue(raw string) (charset, lang, encoded string, ok bool) {
+	i := strings.IndexByte(raw, '\'')
+	if i < 0 {
+		return
+	}
+	j := strings.IndexByte(raw[i+1:], '\'')
+	if j < 0 {
+		return
+	}
+	j += i + 1
+
+	charset, lang, encoded = raw[:i], raw[i+1:j], raw[j+1:]
+	if !strings.EqualFold(charset, "UTF-8") && !strings.EqualFold(charset, "ISO-8859-1") {
+		return
+	}
+	if !stringMatches(lang, isLangChar) {
+		return
+	}
+
+	n := len(encoded)
+	for k := 0; k < n; k++ {
+		ch := encoded[k]
+		switch {
+		case ch == '%':
+			if k+2 >= n || !isHexDigit(encoded[k+1]) || !isHexDigit(encoded[k+2]) {
+				return
+			}
+			k += 2
+		case !isAttrChar(ch):
+			return
+		}
+	}
+
+	ok = true
+	return
+}
+
+func isValidExtValue(raw string) bool {
+	_, _, _, ok := splitExtValue(raw)
+	return ok
+}
+
+func decodeExtValue(raw string) (value, lang string, ok bool) {
+	charset, lang, encoded, ok := splitExtValue(raw)
+	if !ok {
+		return
+	}
+
+	isLatin1 := strings.EqualFold(charset, "ISO-8859-1")
+	if strings.IndexByte(encoded, '%') < 0 {
+		value = encoded
+		return
+	}
+
+	out := make([]byte, 0, len(encoded))
+	n := len(encoded)
+	for k := 0; k < n; k++ {
+		ch := encoded[k]
+		if ch == '%' {
+			ch = unhex(encoded[k+1])<<4 | unhex(encoded[k+2])
+			k += 2
+		}
+		if isLatin1 && ch >= 0x80 {
+			out = utf8.AppendRune(out, rune(ch))
+		} else {
+			out = append(out, ch)
+		}
+	}
+	value = string(out)
+	return
+}
+
+// appendExtValue appends value as an RFC 8187 ext-value.  A value that is
+// not valid UTF-8 is labeled ISO-8859-1, the traditional interpretation of
+// obs-text, rather than mislabeled as UTF-8.
+func appendExtValue(out []byte, value, lang string) []byte {
+	if utf8.ValidString(value) {
+		out = append(out, "UTF-8'"...)
+	} else {
+		out = append(out, "ISO-8859-1'"...)
+	}
+	out = append(out, lang...)
+	out = append(out, '\'')
+	n := len(value)
+	for k := 0; k < n; k++ {
+		ch := value[k]
+		if isAttrChar(ch) {
+			out = append(out, ch)
+		} else {
+			out = append(out, '%', kHexDigits[ch>>4], kHexDigits[ch&0x0f])
+		}
+	}
+	return out
+}
+
+func unhex(ch byte) byte {
+	switch {
+	case isDigit(ch):
+		return ch - '0'
+	case ch >= 'a' && ch <= 'f':
+		return ch - 'a' + 10
+	default:
+		return ch - 'A' + 10
+	}
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+func isLangChar(ch byte) bool {
+	return isAlnum(ch) || ch == '-'
+}
+
+func isAttrChar(ch byte) bool {
+	const SET = "!#$&+-.^_`|~"
+	return isAlnum(ch) || strings.IndexByte(SET, ch) >= 0
+}
@@ -0,0 +1,133 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAcceptable_ExtValue(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect Acceptable
+		Output string
+		Err    error
+	}
+
+	testData := [...]testCase{
+		{
+			Name:  "UTF8",
+			Input: "text/plain;title*=UTF-8''%E2%82%AC%20rates",
+			Expect: Acceptable{"text", "plain", Params{
+				{Name: "title", Value: "€ rates"},
+			}, 1000, nil},
+			Output: "text/plain;title*=UTF-8''%E2%82%AC%20rates",
+		},
+		{
+			Name:  "Language",
+			Input: "text/plain;title*=utf-8'en'rates;q=0.5",
+			Expect: Acceptable{"text", "plain", Params{
+				{Name: "title", Value: "rates", Lang: "en"},
+			}, 500, nil},
+			Output: "text/plain;title*=UTF-8'en'rates;q=0.5",
+		},
+		{
+			Name:  "Latin1",
+			Input: "text/plain;title*=iso-8859-1''%A3%20rates",
+			Expect: Acceptable{"text", "plain", Params{
+				{Name: "title", Value: "£ rates"},
+			}, 1000, nil},
+			Output: "text/plain;title*=UTF-8''%C2%A3%20rates",
+		},
+		{
+			Name:  "ASCII",
+			Input: "text/plain;Title*=UTF-8''rates",
+			Expect: Acceptable{"text", "plain", Params{
+				{Name: "title", Value: "rates"},
+			}, 1000, nil},
+			Output: "text/plain;title=rates",
+		},
+		{
+			Name:  "FailQuoted",
+			Input: `text/plain;title*="UTF-8''rates"`,
+			Err:   &ParseError{`text/plain;title*="UTF-8''rates"`, 18, 0, ExpectExtValue, `"UTF-8''rates"`, ErrExpectExtValue},
+		},
+		{
+			Name:  "FailCharset",
+			Input: "text/plain;title*=EBCDIC''rates",
+			Err:   &ParseError{"text/plain;title*=EBCDIC''rates", 18, 0, ExpectExtValue, "EBCDIC''rates", ErrExpectExtValue},
+		},
+		{
+			Name:  "FailPercent",
+			Input: "text/plain;title*=UTF-8''%E2%8",
+			Err:   &ParseError{"text/plain;title*=UTF-8''%E2%8", 18, 0, ExpectExtValue, "UTF-8''%E2%8", ErrExpectExtValue},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var actual Acceptable
+			err := actual.Parse(row.Input, OptionalSubValue)
+			if !reflect.DeepEqual(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v\n\tactual: %#v", row.Expect, actual)
+			}
+			if output := actual.String(); output != row.Output {
+				t.Errorf("wrong output:\n\texpect: %q\n\tactual: %q", row.Output, output)
+			}
+		})
+	}
+}
+
+func TestAcceptable_AppendExtValue(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  Acceptable
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Quotable",
+			Input:  Acceptable{"text", "plain", Params{{Name: "title", Value: "two words"}}, 1000, nil},
+			Expect: `text/plain;title="two words"`,
+		},
+		{
+			Name:   "NonASCII",
+			Input:  Acceptable{"text", "plain", Params{{Name: "title", Value: "naïve"}}, 1000, nil},
+			Expect: "text/plain;title*=UTF-8''na%C3%AFve",
+		},
+		{
+			Name:   "Control",
+			Input:  Acceptable{"text", "plain", Params{{Name: "title", Value: "a\nb"}}, 1000, nil},
+			Expect: "text/plain;title*=UTF-8''a%0Ab",
+		},
+		{
+			Name:   "Language",
+			Input:  Acceptable{"text", "plain", nil, 1000, Params{{Name: "title", Value: "x", Lang: "de"}}},
+			Expect: "text/plain;q=1;title*=UTF-8'de'x",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual := row.Input.String()
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestElement_ExtValue(t *testing.T) {
+	for e, err := range Elements("text/plain;title*=UTF-8'en'%E2%82%AC", ParseOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value, ok := e.Param("title"); !ok || value != "€" {
+			t.Errorf("wrong result: %q, %t", value, ok)
+		}
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/chronos-tachyon/go-acceptable
 
-go 1.21.1
+go 1.23
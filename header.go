@@ -0,0 +1,44 @@
+package acceptable
+
+import (
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// Field is a parsed HTTP header field.
+//
+// Present distinguishes a field that was absent from the request (in which
+// case List is nil and Accept-style semantics usually mean "anything goes")
+// from one that was sent with an empty value (in which case List is also nil
+// but the client has said something, e.g. "identity only" for
+// Accept-Encoding).
+type Field struct {
+	Name     string
+	Present  bool
+	List     List
+	Warnings []*ParseError
+}
+
+// ParseHeader parses the named field from h.  Multiple field lines are
+// combined with ", " as described in RFC 9110 section 5.3, so offsets in any
+// returned ParseError refer to the combined value.
+func ParseHeader(h http.Header, name string, opts ParseOptions) (Field, error) {
+	name = textproto.CanonicalMIMEHeaderKey(name)
+	field := Field{Name: name}
+
+	lines, found := h[name]
+	if !found {
+		return field, nil
+	}
+	field.Present = true
+
+	var list List
+	warnings, err := list.ParseWithOptions(strings.Join(lines, ", "), opts)
+	if err != nil {
+		return field, err
+	}
+	field.List = list
+	field.Warnings = warnings
+	return field, nil
+}
@@ -0,0 +1,81 @@
+package acceptable
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Header  http.Header
+		Field   string
+		Options ParseOptions
+		Expect  Field
+		Err     error
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Absent",
+			Header: http.Header{"Accept-Encoding": {"gzip"}},
+			Field:  "accept",
+			Expect: Field{Name: "Accept"},
+		},
+		{
+			Name:   "Empty",
+			Header: http.Header{"Accept-Encoding": {""}},
+			Field:  "accept-encoding",
+			Expect: Field{Name: "Accept-Encoding", Present: true},
+		},
+		{
+			Name:   "MultipleLines",
+			Header: http.Header{"Accept": {"text/html", "", "application/json;q=0.5"}},
+			Field:  "Accept",
+			Expect: Field{
+				Name:    "Accept",
+				Present: true,
+				List: List{
+					{"text", "html", nil, 1000, nil},
+					{"application", "json", nil, 500, nil},
+				},
+			},
+		},
+		{
+			Name:    "Lenient",
+			Header:  http.Header{"Accept": {"text/html", "/"}},
+			Field:   "Accept",
+			Options: ParseOptions{Lenient: true},
+			Expect: Field{
+				Name:    "Accept",
+				Present: true,
+				List: List{
+					{"text", "html", nil, 1000, nil},
+				},
+				Warnings: []*ParseError{
+					{"text/html, /", 11, 1, ExpectToken, "/", ErrExpectToken},
+				},
+			},
+		},
+		{
+			Name:   "Strict",
+			Header: http.Header{"Accept": {"text/html", "/"}},
+			Field:  "Accept",
+			Expect: Field{Name: "Accept", Present: true},
+			Err:    &ParseError{"text/html, /", 11, 1, ExpectToken, "/", ErrExpectToken},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, err := ParseHeader(row.Header, row.Field, row.Options)
+			if !reflect.DeepEqual(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v\n\tactual: %#v", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package acceptable
+
+import (
+	"sort"
+	"strings"
+)
+
+// Language ranges (RFC 4647) are parsed as a List in AbsentSubValue mode, so
+// that each range is the Value of an element.  Ranges are tried in priority
+// order: highest Quality first, then in the order the client listed them.
+//
+// A tag that matches more than one range takes its Quality from the most
+// specific of them, so "*;q=0.5, fr;q=0" accepts any language except French.
+
+// FilterLanguages implements basic filtering (RFC 4647 section 3.3.1).  It
+// returns the tags that match at least one range, best first.
+func FilterLanguages(tags []string, ranges List) []string {
+	return filterLanguages(tags, ranges, isBasicLanguageMatch)
+}
+
+// FilterLanguagesExtended implements extended filtering (RFC 4647 section
+// 3.3.2), in which any subtag of a range may be the wildcard "*".  It returns
+// the tags that match at least one range, best first.
+func FilterLanguagesExtended(tags []string, ranges List) []string {
+	return filterLanguages(tags, ranges, isExtendedLanguageMatch)
+}
+
+// LookupLanguage implements lookup (RFC 4647 section 3.4).  It returns the
+// single tag that best matches the ranges, progressively truncating each
+// range until a tag matches it exactly.  If nothing matches, it returns def.
+func LookupLanguage(tags []string, ranges List, def string) string {
+	prio := prioritizeRanges(ranges)
+	for _, r := range prio {
+		if r.Quality <= 0 || r.Value == "*" {
+			continue
+		}
+		for rng := r.Value; rng != ""; rng = truncateRange(rng) {
+			for _, tag := range tags {
+				if strings.EqualFold(tag, rng) && !isExcludedLanguage(tag, prio) {
+					return tag
+				}
+			}
+		}
+	}
+	return def
+}
+
+func filterLanguages(tags []string, ranges List, match func(tag, rng string) bool) []string {
+	type entry struct {
+		tag  string
+		rank int
+	}
+
+	prio := prioritizeRanges(ranges)
+	list := make([]entry, 0, len(tags))
+	for _, tag := range tags {
+		rank := bestRange(tag, prio, match)
+		if rank < 0 || prio[rank].Quality <= 0 {
+			continue
+		}
+		list = append(list, entry{tag, rank})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].rank < list[j].rank
+	})
+
+	if len(list) <= 0 {
+		return nil
+	}
+	out := make([]string, len(list))
+	for i, e := range list {
+		out[i] = e.tag
+	}
+	return out
+}
+
+func prioritizeRanges(ranges List) List {
+	prio := make(List, len(ranges))
+	copy(prio, ranges)
+	sort.SliceStable(prio, func(i, j int) bool {
+		return prio[i].Quality > prio[j].Quality
+	})
+	return prio
+}
+
+// bestRange returns the index of the most specific range in prio that
+// matches tag, or -1 if none do.
+func bestRange(tag string, prio List, match func(tag, rng string) bool) int {
+	best, bestSpecificity := -1, -1
+	for i, r := range prio {
+		if !match(tag, r.Value) {
+			continue
+		}
+		if n := rangeSpecificity(r.Value); n > bestSpecificity {
+			best, bestSpecificity = i, n
+		}
+	}
+	return best
+}
+
+func isExcludedLanguage(tag string, prio List) bool {
+	i := bestRange(tag, prio, isBasicLanguageMatch)
+	return i >= 0 && prio[i].Quality <= 0
+}
+
+func rangeSpecificity(rng string) int {
+	var n int
+	for _, subtag := range strings.Split(rng, "-") {
+		if subtag != "*" {
+			n++
+		}
+	}
+	return n
+}
+
+func isBasicLanguageMatch(tag, rng string) bool {
+	switch {
+	case rng == "*":
+		return true
+	case len(tag) < len(rng):
+		return false
+	case len(tag) > len(rng) && tag[len(rng)] != '-':
+		return false
+	default:
+		return strings.EqualFold(tag[:len(rng)], rng)
+	}
+}
+
+func isExtendedLanguageMatch(tag, rng string) bool {
+	rs := strings.Split(rng, "-")
+	ts := strings.Split(tag, "-")
+
+	if rs[0] != "*" && !strings.EqualFold(rs[0], ts[0]) {
+		return false
+	}
+
+	i, j := 1, 1
+	for i < len(rs) {
+		switch {
+		case rs[i] == "*":
+			i++
+		case j >= len(ts):
+			return false
+		case strings.EqualFold(rs[i], ts[j]):
+			i++
+			j++
+		case len(ts[j]) == 1:
+			return false
+		default:
+			j++
+		}
+	}
+	return true
+}
+
+// truncateRange removes the last subtag of rng, along with any singleton
+// subtag (such as "x") that would be left trailing.
+func truncateRange(rng string) string {
+	i := strings.LastIndexByte(rng, '-')
+	if i < 0 {
+		return ""
+	}
+	rng = rng[:i]
+	if j := strings.LastIndexByte(rng, '-'); j >= 0 && j == len(rng)-2 {
+		rng = rng[:j]
+	}
+	return rng
+}
@@ -0,0 +1,143 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterLanguages(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Tags     []string
+		Ranges   string
+		Extended bool
+		Expect   []string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Empty",
+			Tags:   []string{"en"},
+			Ranges: "",
+		},
+		{
+			Name:   "Basic",
+			Tags:   []string{"de", "de-DE", "de-de-1996", "de-Latn-DE", "de-CH"},
+			Ranges: "de-de",
+			Expect: []string{"de-DE", "de-de-1996"},
+		},
+		{
+			Name:   "Prefix",
+			Tags:   []string{"en-US", "eng", "en-GB", "fr"},
+			Ranges: "en",
+			Expect: []string{"en-US", "en-GB"},
+		},
+		{
+			Name:   "Priority",
+			Tags:   []string{"en-US", "fr-CA", "de"},
+			Ranges: "en;q=0.5, fr, *;q=0.1",
+			Expect: []string{"fr-CA", "en-US", "de"},
+		},
+		{
+			Name:   "Exclusion",
+			Tags:   []string{"en", "fr", "fr-CA", "de"},
+			Ranges: "*;q=0.5, fr;q=0, fr-CA",
+			Expect: []string{"fr-CA", "en", "de"},
+		},
+		{
+			Name:     "Extended",
+			Tags:     []string{"de-DE", "de-de", "de-Latn-DE", "de-Latf-DE", "de-DE-x-goethe", "de-Latn-DE-1996", "de-Deva-DE", "de", "de-x-DE", "de-Deva"},
+			Ranges:   "de-*-DE",
+			Extended: true,
+			Expect:   []string{"de-DE", "de-de", "de-Latn-DE", "de-Latf-DE", "de-DE-x-goethe", "de-Latn-DE-1996", "de-Deva-DE"},
+		},
+		{
+			Name:     "ExtendedLeadingWildcard",
+			Tags:     []string{"de-CH", "fr-CH", "it", "sr-Latn-CH"},
+			Ranges:   "*-CH",
+			Extended: true,
+			Expect:   []string{"de-CH", "fr-CH", "sr-Latn-CH"},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var ranges List
+			if err := ranges.Parse(row.Ranges, AbsentSubValue); err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Ranges, err)
+			}
+
+			var actual []string
+			if row.Extended {
+				actual = FilterLanguagesExtended(row.Tags, ranges)
+			} else {
+				actual = FilterLanguages(row.Tags, ranges)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestLookupLanguage(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Tags   []string
+		Ranges string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Default",
+			Tags:   []string{"fr", "de"},
+			Ranges: "en-US",
+			Expect: "en",
+		},
+		{
+			Name:   "Exact",
+			Tags:   []string{"en", "en-US"},
+			Ranges: "en-US",
+			Expect: "en-US",
+		},
+		{
+			Name:   "Truncation",
+			Tags:   []string{"zh", "zh-Hant"},
+			Ranges: "zh-Hant-CN-x-private1-private2",
+			Expect: "zh-Hant",
+		},
+		{
+			Name:   "Priority",
+			Tags:   []string{"de", "fr"},
+			Ranges: "de-CH;q=0.5, fr-FR",
+			Expect: "fr",
+		},
+		{
+			Name:   "WildcardIgnored",
+			Tags:   []string{"de", "fr"},
+			Ranges: "*, en",
+			Expect: "en",
+		},
+		{
+			Name:   "Exclusion",
+			Tags:   []string{"fr", "fr-CA"},
+			Ranges: "fr-BE, fr;q=0",
+			Expect: "en",
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var ranges List
+			if err := ranges.Parse(row.Ranges, AbsentSubValue); err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Ranges, err)
+			}
+
+			actual := LookupLanguage(row.Tags, ranges, "en")
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -9,13 +9,22 @@ import (
 type List []Acceptable
 
 func (list List) Append(out []byte) []byte {
+	out, _ = list.AppendWithOptions(out, FormatOptions{})
+	return out
+}
+
+func (list List) AppendWithOptions(out []byte, opts FormatOptions) ([]byte, error) {
+	var err error
 	for i, a := range list {
 		if i > 0 {
 			out = append(out, ", "...)
 		}
-		out = a.Append(out)
+		out, err = a.AppendWithOptions(out, opts)
+		if err != nil {
+			return out, err
+		}
 	}
-	return out
+	return out, nil
 }
 
 func (list List) String() string {
@@ -27,53 +36,52 @@ func (list List) MarshalText() ([]byte, error) {
 }
 
 func (list *List) Parse(input string, mode SubValueMode) error {
+	_, err := list.ParseWithOptions(input, ParseOptions{Mode: mode})
+	return err
+}
+
+func (list *List) ParseWithOptions(input string, opts ParseOptions) ([]*ParseError, error) {
 	*list = nil
 
 	var result List
+	warnings, err := parseList(input, &opts, func(e Element) {
+		result = append(result, e.Acceptable())
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	type pstate uint
-	const (
-		rootState pstate = iota
-		quoteState
-		escapeState
-	)
-
-	state := rootState
-	limit := uint(len(input))
-	start := uint(0)
-	for i := uint(0); i < limit; i++ {
-		ch := input[i]
+	*list = result
+	return warnings, nil
+}
+
+// parseList scans input, passing each valid element to keep and collecting
+// warnings as described by ParseOptions.
+func parseList(input string, opts *ParseOptions, keep func(Element)) ([]*ParseError, error) {
+	var warnings []*ParseError
+	var fatal error
+	scanList(input, opts, func(e Element, err error) bool {
+		pe, isParseError := err.(*ParseError)
 		switch {
-		case state == escapeState:
-			state = quoteState
-		case state == quoteState && ch == '\\':
-			state = escapeState
-		case state == quoteState && ch == '"':
-			state = rootState
-		case state == rootState && ch == '"':
-			state = quoteState
-		case state == rootState && ch == ',':
-			var a Acceptable
-			if str := consumeSpace(input[start:i]); str != "" {
-				if err := a.Parse(str, mode); err != nil {
-					return err
-				}
-				result = append(result, a)
-			}
-			start = i + 1
+		case err == nil:
+			keep(e)
+		case opts.Lenient && isParseError && !isFatal(pe):
+			warnings = append(warnings, pe)
+		default:
+			fatal = err
+			return false
 		}
-	}
+		return true
+	})
 
-	if str := consumeSpace(input[start:]); str != "" {
-		var a Acceptable
-		if err := a.Parse(str, mode); err != nil {
-			return err
-		}
-		result = append(result, a)
+	if fatal != nil {
+		return nil, fatal
 	}
+	return warnings, nil
+}
 
-	*list = result
-	return nil
+func isFatal(err *ParseError) bool {
+	return err.Err == ErrHeaderTooLong || err.Err == ErrTooManyElements
 }
 
 func (list *List) UnmarshalText(input []byte) error {
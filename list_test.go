@@ -21,16 +21,16 @@ func TestList_String(t *testing.T) {
 		{
 			Name: "One",
 			Input: List{
-				{"text", "html", nil, 1000},
+				{"text", "html", nil, 1000, nil},
 			},
 			Expect: "text/html",
 		},
 		{
 			Name: "Three",
 			Input: List{
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 900},
-				{"*", "*", nil, 100},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 900, nil},
+				{"*", "*", nil, 100, nil},
 			},
 			Expect: "text/html, text/*;q=0.9, */*;q=0.1",
 		},
@@ -65,18 +65,28 @@ func TestList_Parse(t *testing.T) {
 			Name:  "One",
 			Input: "text/html",
 			Expect: List{
-				{"text", "html", nil, 1000},
+				{"text", "html", nil, 1000, nil},
 			},
 		},
 		{
 			Name:  "Three",
 			Input: "text/html, text/*;q=0.9, */*;q=0.1",
 			Expect: List{
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 900},
-				{"*", "*", nil, 100},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 900, nil},
+				{"*", "*", nil, 100, nil},
 			},
 		},
+		{
+			Name:  "FailSecond",
+			Input: "text/html, ;;, application/json",
+			Err:   &ParseError{"text/html, ;;, application/json", 11, 1, ExpectToken, ";;", ErrExpectToken},
+		},
+		{
+			Name:  "FailQuality",
+			Input: "text/html,  text/*;q=0.9 , */*;q=x",
+			Err:   &ParseError{"text/html,  text/*;q=0.9 , */*;q=x", 33, 2, ExpectQuality, "x", ErrInvalidQuality},
+		},
 	}
 
 	for _, row := range testData {
@@ -109,91 +119,91 @@ func TestList_Sort(t *testing.T) {
 		{
 			Name: "One",
 			Input: List{
-				{"text", "html", nil, 1000},
+				{"text", "html", nil, 1000, nil},
 			},
 			Expect: List{
-				{"text", "html", nil, 1000},
+				{"text", "html", nil, 1000, nil},
 			},
 		},
 		{
 			Name: "Three",
 			Input: List{
-				{"*", "*", nil, 100},
-				{"text", "*", nil, 900},
-				{"text", "html", nil, 1000},
+				{"*", "*", nil, 100, nil},
+				{"text", "*", nil, 900, nil},
+				{"text", "html", nil, 1000, nil},
 			},
 			Expect: List{
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 900},
-				{"*", "*", nil, 100},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 900, nil},
+				{"*", "*", nil, 100, nil},
 			},
 		},
 		{
 			Name: "ThreeSameQ",
 			Input: List{
-				{"*", "*", nil, 1000},
-				{"text", "*", nil, 1000},
-				{"text", "*", paramsCharset, 1000},
-				{"text", "html", nil, 1000},
-				{"text", "html", paramsCharset, 1000},
+				{"*", "*", nil, 1000, nil},
+				{"text", "*", nil, 1000, nil},
+				{"text", "*", paramsCharset, 1000, nil},
+				{"text", "html", nil, 1000, nil},
+				{"text", "html", paramsCharset, 1000, nil},
 			},
 			Expect: List{
-				{"text", "html", paramsCharset, 1000},
-				{"text", "*", paramsCharset, 1000},
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 1000},
-				{"*", "*", nil, 1000},
+				{"text", "html", paramsCharset, 1000, nil},
+				{"text", "*", paramsCharset, 1000, nil},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 1000, nil},
+				{"*", "*", nil, 1000, nil},
 			},
 		},
 		{
 			Name: "ThreeSameValue",
 			Input: List{
-				{"text", "html", nil, 1000},
-				{"text", "html", nil, 900},
-				{"text", "html", nil, 100},
+				{"text", "html", nil, 1000, nil},
+				{"text", "html", nil, 900, nil},
+				{"text", "html", nil, 100, nil},
 			},
 			Expect: List{
-				{"text", "html", nil, 100},
-				{"text", "html", nil, 900},
-				{"text", "html", nil, 1000},
+				{"text", "html", nil, 100, nil},
+				{"text", "html", nil, 900, nil},
+				{"text", "html", nil, 1000, nil},
 			},
 		},
 		{
 			Name: "ComplexOne",
 			Input: List{
-				{"*", "*", nil, 1000},
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 1000},
-				{"image", "*", nil, 1000},
-				{"image", "webp", nil, 0},
-				{"application", "xhtml+xml", nil, 1000},
+				{"*", "*", nil, 1000, nil},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 1000, nil},
+				{"image", "*", nil, 1000, nil},
+				{"image", "webp", nil, 0, nil},
+				{"application", "xhtml+xml", nil, 1000, nil},
 			},
 			Expect: List{
-				{"application", "xhtml+xml", nil, 1000},
-				{"image", "webp", nil, 0},
-				{"image", "*", nil, 1000},
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 1000},
-				{"*", "*", nil, 1000},
+				{"application", "xhtml+xml", nil, 1000, nil},
+				{"image", "webp", nil, 0, nil},
+				{"image", "*", nil, 1000, nil},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 1000, nil},
+				{"*", "*", nil, 1000, nil},
 			},
 		},
 		{
 			Name: "ComplexTwo",
 			Input: List{
-				{"*", "*", nil, 1000},
-				{"text", "html", nil, 1000},
-				{"text", "*", paramsCharset, 1000},
-				{"image", "*", nil, 1000},
-				{"image", "webp", nil, 0},
-				{"application", "xhtml+xml", nil, 1000},
+				{"*", "*", nil, 1000, nil},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", paramsCharset, 1000, nil},
+				{"image", "*", nil, 1000, nil},
+				{"image", "webp", nil, 0, nil},
+				{"application", "xhtml+xml", nil, 1000, nil},
 			},
 			Expect: List{
-				{"text", "*", paramsCharset, 1000},
-				{"application", "xhtml+xml", nil, 1000},
-				{"image", "webp", nil, 0},
-				{"image", "*", nil, 1000},
-				{"text", "html", nil, 1000},
-				{"*", "*", nil, 1000},
+				{"text", "*", paramsCharset, 1000, nil},
+				{"application", "xhtml+xml", nil, 1000, nil},
+				{"image", "webp", nil, 0, nil},
+				{"image", "*", nil, 1000, nil},
+				{"text", "html", nil, 1000, nil},
+				{"*", "*", nil, 1000, nil},
 			},
 		},
 	}
@@ -207,3 +217,181 @@ func TestList_Sort(t *testing.T) {
 		})
 	}
 }
+
+func TestList_ParseWithOptions(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Input    string
+		Options  ParseOptions
+		Expect   List
+		Warnings []*ParseError
+		Err      error
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "LenientClean",
+			Input:   "text/html, */*;q=0.1",
+			Options: ParseOptions{Lenient: true},
+			Expect: List{
+				{"text", "html", nil, 1000, nil},
+				{"*", "*", nil, 100, nil},
+			},
+		},
+		{
+			Name:    "LenientGarbage",
+			Input:   "text/html, ;;, application/json",
+			Options: ParseOptions{Lenient: true},
+			Expect: List{
+				{"text", "html", nil, 1000, nil},
+				{"application", "json", nil, 1000, nil},
+			},
+			Warnings: []*ParseError{
+				{"text/html, ;;, application/json", 11, 1, ExpectToken, ";;", ErrExpectToken},
+			},
+		},
+		{
+			Name:    "LenientAllBad",
+			Input:   "text/html;q=5, gzip",
+			Options: ParseOptions{Mode: RequiredSubValue, Lenient: true},
+			Warnings: []*ParseError{
+				{"text/html;q=5, gzip", 12, 0, ExpectQuality, "5", ErrInvalidQuality},
+				{"text/html;q=5, gzip", 19, 1, ExpectSlash, "", ErrExpectSlash},
+			},
+		},
+		{
+			Name:  "Strict",
+			Input: "text/html, ;;, application/json",
+			Err:   &ParseError{"text/html, ;;, application/json", 11, 1, ExpectToken, ";;", ErrExpectToken},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var actual List
+			warnings, err := actual.ParseWithOptions(row.Input, row.Options)
+			if !reflect.DeepEqual(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if !reflect.DeepEqual(warnings, row.Warnings) {
+				t.Errorf("wrong warnings:\n\texpect: %v\n\tactual: %v", row.Warnings, warnings)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestList_ParseLimits(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Input    string
+		Options  ParseOptions
+		Expect   List
+		Warnings []*ParseError
+		Err      error
+	}
+
+	limits := Limits{MaxBytes: 64, MaxElements: 2, MaxParams: 2, MaxTokenLength: 8}
+
+	testData := [...]testCase{
+		{
+			Name:    "WithinLimits",
+			Input:   "text/html;a=1;q=0.5, */*",
+			Options: ParseOptions{Limits: limits},
+			Expect: List{
+				{"text", "html", Params{{Name: "a", Value: "1"}}, 500, nil},
+				{"*", "*", nil, 1000, nil},
+			},
+		},
+		{
+			Name:    "MaxBytes",
+			Input:   "text/html, application/xhtml+xml, application/xml;q=0.9, */*;q=0.8",
+			Options: ParseOptions{Limits: limits, Lenient: true},
+			Err:     &ParseError{"text/html, application/xhtml+xml, application/xml;q=0.9, */*;q=0.8", 64, 0, ExpectNothing, "", ErrHeaderTooLong},
+		},
+		{
+			Name:    "MaxElements",
+			Input:   "a/a, b/b, c/c",
+			Options: ParseOptions{Limits: limits, Lenient: true},
+			Err:     &ParseError{"a/a, b/b, c/c", 10, 2, ExpectNothing, "", ErrTooManyElements},
+		},
+		{
+			Name:    "MaxParams",
+			Input:   "a/a;x=1;y=2;q=1",
+			Options: ParseOptions{Limits: limits},
+			Err:     &ParseError{"a/a;x=1;y=2;q=1", 11, 0, ExpectNothing, ";q=1", ErrTooManyParams},
+		},
+		{
+			Name:    "MaxTokenLength",
+			Input:   "text/html, application/json",
+			Options: ParseOptions{Limits: limits},
+			Err:     &ParseError{"text/html, application/json", 11, 1, ExpectNothing, "application", ErrTokenTooLong},
+		},
+		{
+			Name:    "MaxTokenLengthQuoted",
+			Input:   `a/a;x="0123456", b/b`,
+			Options: ParseOptions{Limits: limits, Lenient: true},
+			Expect: List{
+				{"b", "b", nil, 1000, nil},
+			},
+			Warnings: []*ParseError{
+				{`a/a;x="0123456", b/b`, 6, 0, ExpectNothing, `"0123456"`, ErrTokenTooLong},
+			},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var actual List
+			warnings, err := actual.ParseWithOptions(row.Input, row.Options)
+			if !reflect.DeepEqual(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if !reflect.DeepEqual(warnings, row.Warnings) {
+				t.Errorf("wrong warnings:\n\texpect: %v\n\tactual: %v", row.Warnings, warnings)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestRawList(t *testing.T) {
+	const input = "text/html ,application/xhtml+xml;q=0.90 ,, image/*;Q=0.50;level=\"1\", ;;,\t*/*; q=0.1"
+
+	var rl RawList
+	if _, err := rl.Parse(input, ParseOptions{Lenient: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const expectUnmodified = "text/html ,application/xhtml+xml;q=0.90 ,, image/*;Q=0.50;level=\"1\",\t*/*; q=0.1"
+	if actual := rl.String(); actual != expectUnmodified {
+		t.Errorf("wrong unmodified result:\n\texpect: %q\n\tactual: %q", expectUnmodified, actual)
+	}
+
+	if raw, ok := rl.Raw(1); !ok || raw != "application/xhtml+xml;q=0.90" {
+		t.Errorf("wrong raw text: %q, %t", raw, ok)
+	}
+
+	rl.List[1].Quality = 800
+	rl.List[2].Extensions[0].Value = "2"
+	const expectModified = "text/html, application/xhtml+xml;q=0.8, image/*;q=0.5;level=2,\t*/*; q=0.1"
+	if actual := rl.String(); actual != expectModified {
+		t.Errorf("wrong modified result:\n\texpect: %q\n\tactual: %q", expectModified, actual)
+	}
+
+	if _, ok := rl.Raw(1); ok {
+		t.Errorf("modified element still reports raw text")
+	}
+
+	var list List
+	if _, err := list.ParseWithOptions(input, ParseOptions{Lenient: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(list[:1], rl.List[:1]) {
+		t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", list[:1], rl.List[:1])
+	}
+}
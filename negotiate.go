@@ -1,94 +1,266 @@
 package acceptable
 
 import (
-	"bytes"
-	"regexp"
+	"context"
 	"sort"
 	"strings"
-	"sync"
 )
 
-var (
-	gPatternMutex sync.Mutex
-	gPatternCache map[string]*regexp.Regexp
-)
+// Result describes how one available offer fared during negotiation.
+type Result struct {
+	// Offer is the available element being ranked.
+	Offer Acceptable
 
-const (
-	kPatternCacheSize = 16
-	kPatternCacheMax  = 32
-)
+	// Preference is the client preference that matched Offer, if Matched.
+	Preference Acceptable
+
+	// Index is the position of Offer in the available list, or -1 for a
+	// fallback Default that is not in it.  PreferenceIndex is the position
+	// of Preference in the preferences list, or -1 if no preference
+	// matched.
+	Index           int
+	PreferenceIndex int
+
+	// Score is the effective quality of Offer, as computed by the
+	// Negotiator's Scorer.  By default, it is the product of the server
+	// and client weights, in the range [0, 1].
+	Score float64
+
+	// Matched is true if a client preference matched Offer, and
+	// SuffixMatch is true if it matched only by structured syntax suffix.
+	Matched     bool
+	SuffixMatch bool
+
+	// Acceptable is true if Offer may be sent to the client at all.
+	Acceptable bool
+
+	// Fallback is true if no offer was acceptable, and Offer was chosen
+	// by the Negotiator's NoMatch policy instead.
+	Fallback bool
+}
+
+// Negotiator holds options that control how offers are matched against
+// client preferences.  The zero value is ready to use, and is what the
+// package-level Negotiate, NegotiateAll and Explain functions use.
+type Negotiator struct {
+	// SuffixQuality enables structured syntax suffix matching (RFC 6839)
+	// if non-zero.  An offer of "application/vnd.foo+json" then matches a
+	// preference for "application/json", and an offer of
+	// "application/json" matches a preference for
+	// "application/problem+json" or "application/*+json", with the
+	// preference's weight scaled by SuffixQuality.  A suffix match is used
+	// only if no preference matches the offer exactly.
+	SuffixQuality Quality
+
+	// Scorer computes the score by which each offer is ranked.  If nil,
+	// ProductScorer is used.
+	Scorer Scorer
 
+	// TieBreak orders offers with equal scores.  If nil, LexicalTieBreak
+	// is used.
+	TieBreak TieBreak
+
+	// NoMatch decides what to send if no offer is acceptable, and Default
+	// is the offer to send under NoMatchDefault.
+	NoMatch NoMatchPolicy
+	Default Acceptable
+}
+
+// Negotiate returns the best of the available offers for the given client
+// preferences.  Each offer is weighted by the most specific preference that
+// matches it, as determined by ComparePrecedence.  If no offer is acceptable
+// to the client, Negotiate returns false, and the server should respond with
+// 406 Not Acceptable.
 func Negotiate(available, preferences List) (Acceptable, bool) {
-	available = maybeSort(available)
-	preferences = maybeSort(preferences)
+	return Negotiator{}.Negotiate(available, preferences)
+}
+
+// NegotiateAll ranks every available offer against the given client
+// preferences, best first.  If preferences is empty, the offers are ranked
+// by their own quality alone.
+func NegotiateAll(available, preferences List) []Result {
+	return Negotiator{}.NegotiateAll(available, preferences)
+}
+
+// Negotiate is like the package-level Negotiate, but uses n's options.  The
+// boolean always reports whether the returned offer is acceptable: if no
+// offer is, Negotiate returns false along with the offer chosen by
+// n.NoMatch, if any.
+func (n Negotiator) Negotiate(available, preferences List) (Acceptable, bool) {
+	r, ok := n.Choose(available, preferences)
+	return r.Offer, ok && !r.Fallback
+}
+
+// NegotiateAll is like the package-level NegotiateAll, but uses n's options.
+func (n Negotiator) NegotiateAll(available, preferences List) []Result {
+	return n.NegotiateAllContext(context.Background(), available, preferences)
+}
+
+// NegotiateAllContext is like NegotiateAll, but passes ctx to the Scorer.
+func (n Negotiator) NegotiateAllContext(ctx context.Context, available, preferences List) []Result {
+	return []Result(n.negotiate(ctx, available, preferences, nil))
+}
 
-	if available == nil {
+func bestOffer(results []Result) (Acceptable, bool) {
+	if len(results) <= 0 || !results[0].Acceptable {
 		return Acceptable{}, false
 	}
+	return results[0].Offer, true
+}
 
-	if preferences == nil {
-		var best Acceptable
-		var hasBest bool
-		for _, a := range available {
-			if a.Quality > 0 && (!hasBest || a.Quality > best.Quality) {
-				best = a
-				hasBest = true
-			}
-		}
-		return best, hasBest
+// negotiate implements NegotiateAll.  If record is not nil, it is called for
+// every preference tried against every offer.
+func (n Negotiator) negotiate(ctx context.Context, available, preferences List, record func(Attempt)) resultList {
+	if len(available) <= 0 {
+		return nil
 	}
 
-	list := make(candidateList, 0, len(available))
-	for _, a := range available {
-		var best Acceptable
-		var hasBest bool
+	order := precedenceOrder(preferences)
+	list := make(resultList, 0, len(available))
+	for index, a := range available {
+		r := Result{
+			Offer:           a,
+			Index:           index,
+			PreferenceIndex: -1,
+		}
 
-		for _, p := range preferences {
-			if !isMatchingValue(a.Value, p.Value) {
-				continue
-			}
+		if len(preferences) <= 0 {
+			r.Score = n.score(ScoreInput{
+				Offer:           a,
+				Index:           index,
+				PreferenceIndex: -1,
+				Weight:          1,
+				Available:       available,
+				Preferences:     preferences,
+				Context:         ctx,
+			})
+			r.Acceptable = r.Score > 0
+			list = append(list, r)
+			continue
+		}
 
-			if !isMatchingValue(a.SubValue, p.SubValue) {
-				continue
-			}
+		// The most specific range that matches exactly determines the
+		// weight, even if that weight is zero (RFC 9110 section 12.5.1).
+		// A suffix match is only a fallback for when no range does, and
+		// a range of weight zero vetoes only the offers it matches exactly.
+		fallback := -1
+		for _, i := range order {
+			p := preferences[i]
 
-			if !isMatchingParams(a.Params, p.Params) {
+			reason := n.matchPreference(a, p)
+			matched := (reason == ReasonMatch || reason == ReasonSuffixMatch)
+			suffix := (reason == ReasonSuffixMatch)
+			if matched && (a.Quality <= 0 || p.Quality <= 0) {
+				reason = ReasonZeroQuality
+			}
+			if record != nil {
+				record(Attempt{
+					Index:           index,
+					Preference:      p,
+					PreferenceIndex: i,
+					Reason:          reason,
+				})
+			}
+			if !matched {
 				continue
 			}
-
-			if a.Quality <= 0 || p.Quality <= 0 {
+			if suffix {
+				if fallback < 0 && p.Quality > 0 {
+					fallback = i
+				}
 				continue
 			}
 
-			best = p
-			hasBest = true
+			r.Preference = p
+			r.PreferenceIndex = i
+			r.Matched = true
 			break
 		}
+		if !r.Matched && fallback >= 0 {
+			r.Preference = preferences[fallback]
+			r.PreferenceIndex = fallback
+			r.Matched = true
+			r.SuffixMatch = true
+		}
 
-		var q float64
-		if hasBest {
-			aq := float64(a.Quality) / 1000
-			bq := float64(best.Quality) / 1000
-			q = aq * bq
+		// A weight of zero from either side vetoes the offer, whatever
+		// the Scorer might say.
+		if r.Matched && a.Quality > 0 && r.Preference.Quality > 0 {
+			weight := 1.0
+			if r.SuffixMatch {
+				weight = float64(n.SuffixQuality) / 1000
+			}
+			r.Score = n.score(ScoreInput{
+				Offer:           a,
+				Index:           index,
+				Preference:      r.Preference,
+				PreferenceIndex: r.PreferenceIndex,
+				Weight:          weight,
+				Available:       available,
+				Preferences:     preferences,
+				Context:         ctx,
+			})
+			r.Acceptable = r.Score > 0
 		}
-		list = append(list, candidate{a, best, q})
+		list = append(list, r)
 	}
 
-	list.Sort()
-	return list[0].a, true
+	tieBreak := n.TieBreak
+	if tieBreak == nil {
+		tieBreak = LexicalTieBreak
+	}
+	list.sort(tieBreak)
+	return list
 }
 
-func maybeSort(list List) List {
+func (n Negotiator) matchPreference(a, p Acceptable) Reason {
+	reason := ReasonMatch
+	switch {
+	case !isMatchingValue(a.Value, p.Value):
+		return ReasonValueMismatch
+	case isMatchingValue(a.SubValue, p.SubValue):
+		// pass
+	case n.SuffixQuality > 0 && isMatchingSuffix(a.SubValue, p.SubValue):
+		reason = ReasonSuffixMatch
+	default:
+		return ReasonSubValueMismatch
+	}
+
+	switch {
+	case !isMatchingParams(a.Params, p.Params):
+		return ReasonParamMismatch
+	default:
+		return reason
+	}
+}
+
+// canonicalizeValues returns a copy of list with each Value replaced by its
+// canonical spelling.
+func canonicalizeValues(list List, canonical func(string) string) List {
 	if len(list) <= 0 {
 		return nil
 	}
-	if list.IsSorted() {
-		return list
+	out := make(List, len(list))
+	for i, a := range list {
+		a.Value = canonical(a.Value)
+		out[i] = a
 	}
-	dupe := make(List, len(list))
-	copy(dupe, list)
-	dupe.Sort()
-	return dupe
+	return out
+}
+
+// precedenceOrder returns the indices of list, most specific range first.
+func precedenceOrder(list List) []int {
+	if len(list) <= 0 {
+		return nil
+	}
+	order := make([]int, len(list))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return lessPrecedence(list[order[i]], list[order[j]])
+	})
+	return order
 }
 
 func isMatchingValue(actual, pattern string) bool {
@@ -102,93 +274,67 @@ func isMatchingValue(actual, pattern string) bool {
 	case strings.EqualFold(pattern, actual):
 		return true
 
+	case strings.IndexByte(pattern, '*') < 0:
+		return false
+
 	default:
-		return compilePattern(pattern).MatchString(actual)
+		return matchGlob(pattern, actual)
 	}
 }
 
-func isMatchingParams(actual, pattern map[string]string) bool {
-	for key, pv := range pattern {
-		av, found := actual[key]
-		if !found || av != pv {
+func isMatchingParams(actual, pattern Params) bool {
+	for _, pp := range pattern {
+		if !hasMatchingParam(actual, pp) {
 			return false
 		}
 	}
 	return true
 }
 
-func compilePattern(pattern string) *regexp.Regexp {
-	gPatternMutex.Lock()
-	defer gPatternMutex.Unlock()
-
-	if rx, found := gPatternCache[pattern]; found {
-		return rx
-	}
-
-	rx := regexp.MustCompile(patternToRegexp(pattern))
-	if gPatternCache == nil {
-		gPatternCache = make(map[string]*regexp.Regexp, kPatternCacheSize)
-	}
-	for len(gPatternCache) >= kPatternCacheMax {
-		var victim string
-		for key := range gPatternCache {
-			victim = key
-			break
+func hasMatchingParam(actual Params, pattern Param) bool {
+	for _, ap := range actual {
+		if strings.EqualFold(ap.Name, pattern.Name) && compareParamValues(ap.Name, ap.Value, pattern.Value) == 0 {
+			return true
 		}
-		delete(gPatternCache, victim)
 	}
-	gPatternCache[pattern] = rx
-	return rx
-}
-
-func patternToRegexp(pattern string) string {
-	buf := gPool.Get().(*bytes.Buffer)
-	defer func() {
-		buf.Reset()
-		gPool.Put(buf)
-	}()
-
-	buf.WriteString("(?i)^")
-	for _, ch := range pattern {
-		if ch == '*' {
-			buf.WriteString(".+")
-		} else {
-			buf.WriteString(regexp.QuoteMeta(string(ch)))
-		}
-	}
-	buf.WriteByte('$')
-	return buf.String()
-}
-
-type candidate struct {
-	a Acceptable
-	p Acceptable
-	q float64
-}
-
-type candidateList []candidate
-
-func (list candidateList) Len() int {
-	return len(list)
-}
-
-func (list candidateList) Swap(i, j int) {
-	list[i], list[j] = list[j], list[i]
+	return false
 }
 
-func (list candidateList) Less(i, j int) bool {
-	a, b := list[i], list[j]
-	cmp := compareFloats(a.q, b.q)
-	if cmp != 0 {
-		return cmp > 0
-	}
-	cmp = a.a.CompareTo(b.a)
-	if cmp != 0 {
-		return cmp < 0
+// matchGlob reports whether str matches pattern, ignoring ASCII case, where
+// each '*' in pattern matches one or more bytes.  It runs in O(len(pattern)
+// * len(str)) time without allocating.
+func matchGlob(pattern, str string) bool {
+	var p, s int
+	star, next := -1, 0
+	for s < len(str) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star = p
+			p++
+			s++
+			next = s
+		case p < len(pattern) && toLower(pattern[p]) == toLower(str[s]):
+			p++
+			s++
+		case star >= 0:
+			p = star + 1
+			next++
+			s = next
+		default:
+			return false
+		}
 	}
-	return false
+	return p == len(pattern)
 }
 
-func (list candidateList) Sort() {
-	sort.Sort(list)
+type resultList []Result
+
+func (list resultList) sort(tieBreak TieBreak) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if cmp := compareFloats(a.Score, b.Score); cmp != 0 {
+			return cmp > 0
+		}
+		return tieBreak(a, b) < 0
+	})
 }
@@ -21,31 +21,69 @@ func TestNegotiate(t *testing.T) {
 		{
 			Name: "NoneAvailable",
 			Preferences: List{
-				{"text", "html", nil, 1000},
-				{"text", "*", nil, 900},
-				{"*", "*", nil, 100},
+				{"text", "html", nil, 1000, nil},
+				{"text", "*", nil, 900, nil},
+				{"*", "*", nil, 100, nil},
 			},
 		},
 		{
 			Name: "NonePreferred",
 			Available: List{
-				{"text", "html", nil, 1000},
-				{"application", "json", nil, 999},
+				{"text", "html", nil, 1000, nil},
+				{"application", "json", nil, 999, nil},
 			},
-			Expect:   Acceptable{"text", "html", nil, 1000},
+			Expect:   Acceptable{"text", "html", nil, 1000, nil},
 			ExpectOK: true,
 		},
 		{
 			Name: "Specificity",
 			Available: List{
-				{"text", "html", nil, 1000},
-				{"text", "plain", paramsCharset, 1000},
+				{"text", "html", nil, 1000, nil},
+				{"text", "plain", paramsCharset, 1000, nil},
 			},
 			Preferences: List{
-				{"text", "*", paramsCharset, 1000},
-				{"text", "html", nil, 999},
+				{"text", "*", paramsCharset, 1000, nil},
+				{"text", "html", nil, 999, nil},
 			},
-			Expect:   Acceptable{"text", "plain", paramsCharset, 1000},
+			Expect:   Acceptable{"text", "plain", paramsCharset, 1000, nil},
+			ExpectOK: true,
+		},
+		{
+			Name: "ExtensionsIgnored",
+			Available: List{
+				{"text", "html", paramsLevel, 1000, nil},
+				{"text", "plain", nil, 500, nil},
+			},
+			Preferences: List{
+				{"text", "html", paramsLevel, 1000, paramsFoo},
+				{"text", "plain", nil, 1000, nil},
+			},
+			Expect:   Acceptable{"text", "html", paramsLevel, 1000, nil},
+			ExpectOK: true,
+		},
+		{
+			Name: "NoneAcceptable",
+			Available: List{
+				{"text", "html", nil, 1000, nil},
+				{"application", "json", nil, 1000, nil},
+			},
+			Preferences: List{
+				{"image", "*", nil, 1000, nil},
+				{"text", "html", nil, 0, nil},
+			},
+		},
+		{
+			Name: "Precedence",
+			Available: List{
+				{"text", "html", paramsLevel, 1000, nil},
+				{"application", "json", nil, 500, nil},
+			},
+			Preferences: List{
+				{"*", "*", paramsLevel, 200, nil},
+				{"text", "html", nil, 800, nil},
+				{"*", "*", nil, 500, nil},
+			},
+			Expect:   Acceptable{"text", "html", paramsLevel, 1000, nil},
 			ExpectOK: true,
 		},
 	}
@@ -59,3 +97,233 @@ func TestNegotiate(t *testing.T) {
 		})
 	}
 }
+
+func TestNegotiateAll(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Available   List
+		Preferences List
+		Expect      []Result
+	}
+
+	html := Acceptable{"text", "html", nil, 1000, nil}
+	json := Acceptable{"application", "json", nil, 900, nil}
+	png := Acceptable{"image", "png", nil, 1000, nil}
+
+	testData := [...]testCase{
+		{
+			Name: "Empty",
+		},
+		{
+			Name:      "NonePreferred",
+			Available: List{json, html},
+			Expect: []Result{
+				{Offer: html, Index: 1, PreferenceIndex: -1, Score: 1, Acceptable: true},
+				{Offer: json, Index: 0, PreferenceIndex: -1, Score: 0.9, Acceptable: true},
+			},
+		},
+		{
+			Name:      "Ranked",
+			Available: List{html, json, png},
+			Preferences: List{
+				{"text", "html", nil, 500, nil},
+				{"application", "*", nil, 1000, nil},
+			},
+			Expect: []Result{
+				{
+					Offer:           json,
+					Preference:      Acceptable{"application", "*", nil, 1000, nil},
+					Index:           1,
+					PreferenceIndex: 1,
+					Score:           0.9,
+					Matched:         true,
+					Acceptable:      true,
+				},
+				{
+					Offer:           html,
+					Preference:      Acceptable{"text", "html", nil, 500, nil},
+					Index:           0,
+					PreferenceIndex: 0,
+					Score:           0.5,
+					Matched:         true,
+					Acceptable:      true,
+				},
+				{Offer: png, Index: 2, PreferenceIndex: -1},
+			},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual := NegotiateAll(row.Available, row.Preferences)
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestNegotiate_RFCExamples(t *testing.T) {
+	type offer struct {
+		Input  string
+		Expect float64
+	}
+
+	type testCase struct {
+		Name       string
+		Negotiator Negotiator
+		Accept     string
+		Offers     []offer
+	}
+
+	testData := [...]testCase{
+		{
+			// RFC 9110 section 12.5.1
+			Name:   "RFC9110",
+			Accept: "text/*;q=0.3, text/plain;q=0.7, text/plain;format=flowed, text/plain;format=fixed;q=0.4, */*;q=0.5",
+			Offers: []offer{
+				{"text/plain;format=flowed", 1},
+				{"text/plain", 0.7},
+				{"text/html", 0.3},
+				{"image/jpeg", 0.5},
+				{"text/plain;format=fixed", 0.4},
+				{"text/html;level=3", 0.3},
+			},
+		},
+		{
+			// RFC 7231 section 5.3.2
+			Name:   "RFC7231",
+			Accept: "text/*;q=0.3, text/html;q=0.7, text/html;level=1, text/html;level=2;q=0.4, */*;q=0.5",
+			Offers: []offer{
+				{"text/html;level=1", 1},
+				{"text/html", 0.7},
+				{"text/plain", 0.3},
+				{"image/jpeg", 0.5},
+				{"text/html;level=2", 0.4},
+				{"text/html;level=3", 0.7},
+			},
+		},
+		{
+			// RFC 9110 section 12.5.1
+			Name:   "Audio",
+			Accept: "audio/*; q=0.2, audio/basic",
+			Offers: []offer{
+				{"audio/basic", 1},
+				{"audio/mpeg", 0.2},
+				{"video/mpeg", 0},
+			},
+		},
+		{
+			Name:   "ExcludeType",
+			Accept: "text/html;q=0, */*",
+			Offers: []offer{
+				{"text/html", 0},
+				{"text/plain", 1},
+				{"application/json", 1},
+			},
+		},
+		{
+			Name:   "ExcludeRange",
+			Accept: "text/*;q=0, text/plain, */*;q=0.1",
+			Offers: []offer{
+				{"text/plain", 1},
+				{"text/html", 0},
+				{"image/png", 0.1},
+			},
+		},
+		{
+			Name:   "ExcludeParams",
+			Accept: "text/html;level=1;q=0, text/html",
+			Offers: []offer{
+				{"text/html;level=1", 0},
+				{"text/html;level=2", 1},
+				{"text/html", 1},
+			},
+		},
+		{
+			Name:   "ExcludeAll",
+			Accept: "*/*;q=0",
+			Offers: []offer{
+				{"text/html", 0},
+				{"image/png", 0},
+			},
+		},
+		{
+			Name:       "ExcludeSuffixBase",
+			Negotiator: Negotiator{SuffixQuality: 500},
+			Accept:     "application/json;q=0, application/*+json",
+			Offers: []offer{
+				{"application/vnd.foo+json", 1},
+				{"application/json", 0},
+			},
+		},
+		{
+			Name:       "ExcludeSuffixBaseWildcard",
+			Negotiator: Negotiator{SuffixQuality: 500},
+			Accept:     "application/json;q=0, */*",
+			Offers: []offer{
+				{"application/vnd.foo+json", 1},
+				{"application/json", 0},
+				{"text/html", 1},
+			},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var preferences List
+			if err := preferences.Parse(row.Accept, RequiredSubValue); err != nil {
+				t.Fatalf("failed to parse %q: %v", row.Accept, err)
+			}
+
+			for _, o := range row.Offers {
+				var a Acceptable
+				if err := a.Parse(o.Input, RequiredSubValue); err != nil {
+					t.Fatalf("failed to parse %q: %v", o.Input, err)
+				}
+
+				results := row.Negotiator.NegotiateAll(List{a}, preferences)
+				if len(results) != 1 {
+					t.Fatalf("%s: wrong number of results: %d", o.Input, len(results))
+				}
+				r := results[0]
+				if r.Score != o.Expect || r.Acceptable != (o.Expect > 0) {
+					t.Errorf("%s: wrong result:\n\texpect: %v, %t\n\tactual: %v, %t", o.Input, o.Expect, o.Expect > 0, r.Score, r.Acceptable)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	type testCase struct {
+		Pattern string
+		Input   string
+		Expect  bool
+	}
+
+	testData := [...]testCase{
+		{"te*t", "text", true},
+		{"te*t", "TEXT", true},
+		{"te*t", "tet", false},
+		{"te*t", "teaabbt", true},
+		{"te*t", "texts", false},
+		{"*+json", "vnd.foo+json", true},
+		{"*+json", "+json", false},
+		{"x-*", "x-foo", true},
+		{"x-*", "x-", false},
+		{"*a*b", "xaab", true},
+		{"*a*b", "xabx", false},
+		{"a**b", "axyb", true},
+		{"a**b", "axb", false},
+		{"*a*a*a*a*a*a*a*a*b", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", false},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Pattern+"/"+row.Input, func(t *testing.T) {
+			if actual := matchGlob(row.Pattern, row.Input); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %t\n\tactual: %t", row.Expect, actual)
+			}
+		})
+	}
+}
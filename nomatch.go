@@ -0,0 +1,75 @@
+package acceptable
+
+import (
+	"context"
+	"fmt"
+)
+
+// NoMatchPolicy decides what a Negotiator sends if none of the available
+// offers is acceptable to the client.  RFC 9110 section 12.1 permits a server
+// either to respond with 406 Not Acceptable or to disregard the client's
+// preferences and send something anyway.
+type NoMatchPolicy uint
+
+const (
+	// NoMatchFail sends nothing; the server should respond with 406.
+	NoMatchFail NoMatchPolicy = iota
+
+	// NoMatchDefault sends the Negotiator's Default offer.
+	NoMatchDefault
+
+	// NoMatchFirst sends the first available offer.
+	NoMatchFirst
+)
+
+var gNoMatchPolicyNames = [...]string{
+	"NoMatchFail",
+	"NoMatchDefault",
+	"NoMatchFirst",
+}
+
+func (policy NoMatchPolicy) String() string {
+	if policy < NoMatchPolicy(len(gNoMatchPolicyNames)) {
+		return gNoMatchPolicyNames[policy]
+	}
+	return fmt.Sprintf("NoMatchPolicy(%d)", uint(policy))
+}
+
+// Choose returns the result for the best acceptable offer.  If no offer is
+// acceptable, it applies n.NoMatch: the returned result then has Fallback
+// set, or Choose returns false if the policy is NoMatchFail.
+func (n Negotiator) Choose(available, preferences List) (Result, bool) {
+	return n.ChooseContext(context.Background(), available, preferences)
+}
+
+// ChooseContext is like Choose, but passes ctx to the Scorer, so that it can
+// take request state into account.
+func (n Negotiator) ChooseContext(ctx context.Context, available, preferences List) (Result, bool) {
+	results := n.negotiate(ctx, available, preferences, nil)
+	if len(results) > 0 && results[0].Acceptable {
+		return results[0], true
+	}
+
+	switch n.NoMatch {
+	case NoMatchDefault:
+		if n.Default.Value == "" {
+			break
+		}
+		for _, r := range results {
+			if r.Offer.EqualTo(n.Default) {
+				r.Fallback = true
+				return r, true
+			}
+		}
+		return Result{Offer: n.Default, Index: -1, PreferenceIndex: -1, Fallback: true}, true
+
+	case NoMatchFirst:
+		for _, r := range results {
+			if r.Index == 0 {
+				r.Fallback = true
+				return r, true
+			}
+		}
+	}
+	return Result{}, false
+}
@@ -0,0 +1,134 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegotiator_Choose(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Negotiator  Negotiator
+		Available   List
+		Preferences List
+		Expect      Result
+		ExpectOK    bool
+	}
+
+	html := Acceptable{"text", "html", nil, 1000, nil}
+	json := Acceptable{"application", "json", nil, 1000, nil}
+	plain := Acceptable{"text", "plain", nil, 1000, nil}
+	image := List{{"image", "*", nil, 1000, nil}}
+
+	testData := [...]testCase{
+		{
+			Name:        "Match",
+			Negotiator:  Negotiator{NoMatch: NoMatchFirst},
+			Available:   List{html, json},
+			Preferences: List{json},
+			Expect: Result{
+				Offer:           json,
+				Preference:      json,
+				Index:           1,
+				PreferenceIndex: 0,
+				Score:           1,
+				Matched:         true,
+				Acceptable:      true,
+			},
+			ExpectOK: true,
+		},
+		{
+			Name:        "Fail",
+			Available:   List{html, json},
+			Preferences: image,
+		},
+		{
+			Name:        "First",
+			Negotiator:  Negotiator{NoMatch: NoMatchFirst},
+			Available:   List{json, html},
+			Preferences: image,
+			Expect:      Result{Offer: json, PreferenceIndex: -1, Fallback: true},
+			ExpectOK:    true,
+		},
+		{
+			Name:        "FirstNoneAvailable",
+			Negotiator:  Negotiator{NoMatch: NoMatchFirst},
+			Preferences: image,
+		},
+		{
+			Name:        "Default",
+			Negotiator:  Negotiator{NoMatch: NoMatchDefault, Default: html},
+			Available:   List{json, html},
+			Preferences: image,
+			Expect:      Result{Offer: html, Index: 1, PreferenceIndex: -1, Fallback: true},
+			ExpectOK:    true,
+		},
+		{
+			Name:        "DefaultNotAvailable",
+			Negotiator:  Negotiator{NoMatch: NoMatchDefault, Default: plain},
+			Available:   List{json, html},
+			Preferences: image,
+			Expect:      Result{Offer: plain, Index: -1, PreferenceIndex: -1, Fallback: true},
+			ExpectOK:    true,
+		},
+		{
+			Name:        "DefaultUnset",
+			Negotiator:  Negotiator{NoMatch: NoMatchDefault},
+			Available:   List{json, html},
+			Preferences: image,
+		},
+		{
+			Name:        "Vetoed",
+			Negotiator:  Negotiator{NoMatch: NoMatchFirst},
+			Available:   List{html},
+			Preferences: List{{"text", "html", nil, 0, nil}},
+			Expect: Result{
+				Offer:           html,
+				Preference:      Acceptable{"text", "html", nil, 0, nil},
+				PreferenceIndex: 0,
+				Matched:         true,
+				Fallback:        true,
+			},
+			ExpectOK: true,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, ok := row.Negotiator.Choose(row.Available, row.Preferences)
+			if ok != row.ExpectOK || !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %+v, %t\n\tactual: %+v, %t", row.Expect, row.ExpectOK, actual, ok)
+			}
+
+			// Negotiate returns the fallback offer, but reports that it
+			// is not acceptable.
+			expectOK := row.ExpectOK && !row.Expect.Fallback
+			offer, ok := row.Negotiator.Negotiate(row.Available, row.Preferences)
+			if ok != expectOK || !reflect.DeepEqual(offer, row.Expect.Offer) {
+				t.Errorf("wrong Negotiate result:\n\texpect: %v, %t\n\tactual: %v, %t", row.Expect.Offer, expectOK, offer, ok)
+			}
+		})
+	}
+}
+
+func TestNoMatchPolicy_String(t *testing.T) {
+	type testCase struct {
+		Input  NoMatchPolicy
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{NoMatchFail, "NoMatchFail"},
+		{NoMatchDefault, "NoMatchDefault"},
+		{NoMatchFirst, "NoMatchFirst"},
+		{NoMatchPolicy(99), "NoMatchPolicy(99)"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Expect, func(t *testing.T) {
+			if actual := row.Input.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package acceptable
+
+const raceEnabled = false
@@ -0,0 +1,108 @@
+package acceptable
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ObsTextPolicy controls how bytes 0x80-0xFF (obs-text, in RFC 9110 terms)
+// are treated inside quoted strings.
+//
+// The zero value, ObsTextDefault, follows the robustness principle: parsing
+// accepts obs-text as opaque bytes, and serializing avoids generating it by
+// switching parameter values to the RFC 8187 extended form.
+type ObsTextPolicy uint
+
+const (
+	ObsTextDefault ObsTextPolicy = iota
+	ObsTextAllow
+	ObsTextReject
+	ObsTextUTF8
+)
+
+var gObsTextPolicyNames = [...]string{
+	"ObsTextDefault",
+	"ObsTextAllow",
+	"ObsTextReject",
+	"ObsTextUTF8",
+}
+
+func (policy ObsTextPolicy) String() string {
+	if policy < ObsTextPolicy(len(gObsTextPolicyNames)) {
+		return gObsTextPolicyNames[policy]
+	}
+	return fmt.Sprintf("ObsTextPolicy(%d)", uint(policy))
+}
+
+// FormatOptions controls how Acceptable and List values are serialized.
+type FormatOptions struct {
+	ObsText ObsTextPolicy
+}
+
+// checkObsText returns the index of the first byte of str that violates the
+// policy, along with the reason, or -1 if str is acceptable.
+func checkObsText(str string, policy ObsTextPolicy) (int, error) {
+	switch policy {
+	case ObsTextReject:
+		for i := 0; i < len(str); i++ {
+			if isObsText(str[i]) {
+				return i, ErrObsText
+			}
+		}
+
+	case ObsTextUTF8:
+		for i := 0; i < len(str); {
+			r, size := utf8.DecodeRuneInString(str[i:])
+			if r == utf8.RuneError && size <= 1 {
+				return i, ErrInvalidUTF8
+			}
+			i += size
+		}
+	}
+	return -1, nil
+}
+
+func hasObsText(str string) bool {
+	return !stringMatches(str, func(ch byte) bool { return !isObsText(ch) })
+}
+
+func appendValueWith(out []byte, value string, policy ObsTextPolicy) ([]byte, error) {
+	if _, err := checkObsText(value, policy); err != nil {
+		return out, fmt.Errorf("%w in %q", err, value)
+	}
+	return appendToken(out, value), nil
+}
+
+// appendParamsWith appends params, each preceded by ';'.  If beforeWeight is
+// true, a parameter named "q" is written in the extended form, as "q*", so
+// that it is not mistaken for the weight when parsed back.
+func appendParamsWith(out []byte, params Params, policy ObsTextPolicy, beforeWeight bool) ([]byte, error) {
+	for _, p := range params {
+		out = append(out, ';')
+		out = appendToken(out, p.Name)
+
+		if (policy == ObsTextReject || policy == ObsTextUTF8) && !utf8.ValidString(p.Value) {
+			return out, fmt.Errorf("%w in parameter %q", ErrInvalidUTF8, p.Name)
+		}
+
+		useExt := p.Lang != "" || hasControl(p.Value) || (beforeWeight && strings.EqualFold(p.Name, "q"))
+		if policy == ObsTextDefault || policy == ObsTextReject {
+			useExt = useExt || hasObsText(p.Value)
+		}
+
+		if useExt {
+			out = append(out, "*="...)
+			out = appendExtValue(out, p.Value, p.Lang)
+			continue
+		}
+
+		out = append(out, '=')
+		out = appendToken(out, p.Value)
+	}
+	return out, nil
+}
+
+func hasControl(str string) bool {
+	return !stringMatches(str, func(ch byte) bool { return !isControl(ch) })
+}
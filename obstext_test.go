@@ -0,0 +1,148 @@
+package acceptable
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAcceptable_ParseObsText(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Policy ObsTextPolicy
+		Expect Acceptable
+		Err    error
+	}
+
+	paramsCafe := Params{{Name: "title", Value: "café"}}
+	paramsLatin1 := Params{{Name: "title", Value: "caf\xe9"}}
+
+	testData := [...]testCase{
+		{
+			Name:   "DefaultUTF8",
+			Input:  "text/plain;title=\"café\"",
+			Expect: Acceptable{"text", "plain", paramsCafe, 1000, nil},
+		},
+		{
+			Name:   "DefaultLatin1",
+			Input:  "text/plain;title=\"caf\xe9\"",
+			Expect: Acceptable{"text", "plain", paramsLatin1, 1000, nil},
+		},
+		{
+			Name:   "AllowLatin1",
+			Input:  "text/plain;title=\"caf\xe9\"",
+			Policy: ObsTextAllow,
+			Expect: Acceptable{"text", "plain", paramsLatin1, 1000, nil},
+		},
+		{
+			Name:   "UTF8Valid",
+			Input:  "text/plain;title=\"café\"",
+			Policy: ObsTextUTF8,
+			Expect: Acceptable{"text", "plain", paramsCafe, 1000, nil},
+		},
+		{
+			Name:   "UTF8Invalid",
+			Input:  "text/plain;title=\"caf\xe9\"",
+			Policy: ObsTextUTF8,
+			Err:    &ParseError{"text/plain;title=\"caf\xe9\"", 21, 0, ExpectNothing, "\xe9\"", ErrInvalidUTF8},
+		},
+		{
+			Name:   "UTF8InvalidExtValue",
+			Input:  "text/plain;title*=UTF-8''caf%E9",
+			Policy: ObsTextUTF8,
+			Err:    &ParseError{"text/plain;title*=UTF-8''caf%E9", 18, 0, ExpectNothing, "UTF-8''caf%E9", ErrInvalidUTF8},
+		},
+		{
+			Name:   "RejectObsText",
+			Input:  "text/plain;title=\"café\"",
+			Policy: ObsTextReject,
+			Err:    &ParseError{"text/plain;title=\"café\"", 21, 0, ExpectNothing, "é\"", ErrObsText},
+		},
+		{
+			Name:   "RejectExtValueOK",
+			Input:  "text/plain;title*=UTF-8''caf%C3%A9",
+			Policy: ObsTextReject,
+			Expect: Acceptable{"text", "plain", paramsCafe, 1000, nil},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var actual Acceptable
+			err := actual.ParseWithOptions(row.Input, ParseOptions{ObsText: row.Policy})
+			if !reflect.DeepEqual(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestAcceptable_AppendObsText(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  Acceptable
+		Policy ObsTextPolicy
+		Expect string
+		Err    error
+	}
+
+	cafe := Acceptable{"text", "plain", Params{{Name: "title", Value: "café"}}, 1000, nil}
+	latin1 := Acceptable{"text", "plain", Params{{Name: "title", Value: "caf\xe9"}}, 1000, nil}
+	value := Acceptable{"text", "plaín", nil, 1000, nil}
+
+	testData := [...]testCase{
+		{"DefaultUTF8", cafe, ObsTextDefault, "text/plain;title*=UTF-8''caf%C3%A9", nil},
+		{"DefaultLatin1", latin1, ObsTextDefault, "text/plain;title*=ISO-8859-1''caf%E9", nil},
+		{"DefaultValue", value, ObsTextDefault, "text/\"plaín\"", nil},
+		{"AllowUTF8", cafe, ObsTextAllow, "text/plain;title=\"café\"", nil},
+		{"AllowLatin1", latin1, ObsTextAllow, "text/plain;title=\"caf\xe9\"", nil},
+		{"RejectUTF8", cafe, ObsTextReject, "text/plain;title*=UTF-8''caf%C3%A9", nil},
+		{"RejectLatin1", latin1, ObsTextReject, "text/plain;title", ErrInvalidUTF8},
+		{"RejectValue", value, ObsTextReject, "text/", ErrObsText},
+		{"UTF8Valid", cafe, ObsTextUTF8, "text/plain;title=\"café\"", nil},
+		{"UTF8Latin1", latin1, ObsTextUTF8, "text/plain;title", ErrInvalidUTF8},
+		{"UTF8Value", value, ObsTextUTF8, "text/\"plaín\"", nil},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			out, err := row.Input.AppendWithOptions(nil, FormatOptions{ObsText: row.Policy})
+			if !errors.Is(err, row.Err) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", row.Err, err)
+			}
+			if actual := string(out); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestRawList_AppendObsText(t *testing.T) {
+	var rl RawList
+	_, err := rl.Parse("text/plain;title=\"café\", text/html", ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := rl.AppendWithOptions(nil, FormatOptions{})
+	const expectDefault = "text/plain;title*=UTF-8''caf%C3%A9, text/html"
+	if err != nil || string(out) != expectDefault {
+		t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q, %v", expectDefault, out, err)
+	}
+
+	out, err = rl.AppendWithOptions(nil, FormatOptions{ObsText: ObsTextAllow})
+	const expectAllow = "text/plain;title=\"café\", text/html"
+	if err != nil || string(out) != expectAllow {
+		t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q, %v", expectAllow, out, err)
+	}
+
+	out, err = rl.AppendWithOptions(nil, FormatOptions{ObsText: ObsTextReject})
+	const expectReject = "text/plain;title*=UTF-8''caf%C3%A9, text/html"
+	if err != nil || string(out) != expectReject {
+		t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q, %v", expectReject, out, err)
+	}
+}
@@ -0,0 +1,50 @@
+package acceptable
+
+// ParseOptions controls how Acceptable and List values are parsed.
+//
+// The zero value parses strictly with OptionalSubValue: the first malformed
+// element aborts the whole parse.  With Lenient set, List parsing instead
+// drops malformed elements, keeps the valid ones, and reports one ParseError
+// per dropped element as a warning.
+//
+// Strict enforces the RFC 9110 grammar exactly: no whitespace around '/' or
+// '=', at most three decimal places in an unquoted qvalue, and '*' only as a
+// complete type, subtype, or value, never as "*/subtype".
+//
+// ObsText selects how bytes 0x80-0xFF inside quoted strings, and inside
+// decoded RFC 8187 extended values, are treated.
+//
+// Limits bounds the resources a single header may consume.  Violations of
+// MaxBytes or MaxElements always abort the parse, even in Lenient mode; the
+// per-element limits cause the offending element to be dropped instead.
+type ParseOptions struct {
+	Mode    SubValueMode
+	Lenient bool
+	Strict  bool
+	ObsText ObsTextPolicy
+	Limits  Limits
+}
+
+// Limits caps the size of parsed input.  A zero field means "no limit".
+type Limits struct {
+	MaxBytes       int
+	MaxElements    int
+	MaxParams      int
+	MaxTokenLength int
+}
+
+// DefaultLimits is a conservative set of limits suitable for parsing headers
+// received on public endpoints.
+var DefaultLimits = Limits{
+	MaxBytes:       8192,
+	MaxElements:    64,
+	MaxParams:      16,
+	MaxTokenLength: 256,
+}
+
+func (limits *Limits) checkBytes(input string) *ParseError {
+	if limits.MaxBytes > 0 && len(input) > limits.MaxBytes {
+		return newSentinelError(input, limits.MaxBytes, ErrHeaderTooLong, "")
+	}
+	return nil
+}
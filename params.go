@@ -0,0 +1,148 @@
+package acceptable
+
+import (
+	"sort"
+	"strings"
+)
+
+// Param is a single name=value parameter.  Names are stored in lower case by
+// the parser and compared case-insensitively by the lookup helpers.
+//
+// Parameters sent in the RFC 8187 extended form (name*=UTF-8'en'%E2%82%AC)
+// are decoded: Name drops the trailing '*', Value holds the Unicode text, and
+// Lang holds the language tag, if any.  The extended form is used again when
+// serializing if Lang is set or Value cannot be sent as a quoted-string.
+type Param struct {
+	Name  string
+	Value string
+	Lang  string
+}
+
+// Params is an ordered collection of parameters.  Unlike a map, it preserves
+// the order in which parameters appeared and allows a name to repeat.
+type Params []Param
+
+func ParamsFromMap(m map[string]string) Params {
+	if len(m) <= 0 {
+		return nil
+	}
+
+	params := make(Params, 0, len(m))
+	for name, value := range m {
+		params = append(params, Param{Name: name, Value: value})
+	}
+	params.sort()
+	return params
+}
+
+func (params Params) Len() int {
+	return len(params)
+}
+
+func (params Params) Index(name string) int {
+	for i, p := range params {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (params Params) Has(name string) bool {
+	return params.Index(name) >= 0
+}
+
+func (params Params) Get(name string) (string, bool) {
+	if i := params.Index(name); i >= 0 {
+		return params[i].Value, true
+	}
+	return "", false
+}
+
+func (params Params) GetAll(name string) []string {
+	var values []string
+	for _, p := range params {
+		if strings.EqualFold(p.Name, name) {
+			values = append(values, p.Value)
+		}
+	}
+	return values
+}
+
+func (params *Params) Add(name, value string) {
+	*params = append(*params, Param{Name: name, Value: value})
+}
+
+func (params *Params) Set(name, value string) {
+	i := params.Index(name)
+	if i < 0 {
+		params.Add(name, value)
+		return
+	}
+	(*params)[i] = Param{Name: (*params)[i].Name, Value: value}
+	params.delFrom(name, i+1)
+}
+
+func (params *Params) Del(name string) {
+	params.delFrom(name, 0)
+}
+
+func (params *Params) delFrom(name string, start int) {
+	list := *params
+	j := start
+	for i := start; i < len(list); i++ {
+		if !strings.EqualFold(list[i].Name, name) {
+			list[j] = list[i]
+			j++
+		}
+	}
+	for i := j; i < len(list); i++ {
+		list[i] = Param{}
+	}
+	list = list[:j]
+	if len(list) <= 0 {
+		list = nil
+	}
+	*params = list
+}
+
+func (params Params) Clone() Params {
+	if len(params) <= 0 {
+		return nil
+	}
+	dupe := make(Params, len(params))
+	copy(dupe, params)
+	return dupe
+}
+
+func (params Params) Map() map[string]string {
+	if len(params) <= 0 {
+		return nil
+	}
+	m := make(map[string]string, len(params))
+	for _, p := range params {
+		key := strings.ToLower(p.Name)
+		if _, found := m[key]; !found {
+			m[key] = p.Value
+		}
+	}
+	return m
+}
+
+func (params Params) sort() {
+	sort.SliceStable(params, func(i, j int) bool {
+		return compareParam(params[i], params[j]) < 0
+	})
+}
+
+func (params Params) sorted() Params {
+	isSorted := sort.SliceIsSorted(params, func(i, j int) bool {
+		return compareParam(params[i], params[j]) < 0
+	})
+	if isSorted {
+		return params
+	}
+	dupe := params.Clone()
+	dupe.sort()
+	return dupe
+}
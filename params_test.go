@@ -0,0 +1,89 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	params := Params{
+		{Name: "boundary", Value: "x"},
+		{Name: "acme", Value: "2"},
+		{Name: "acme", Value: "1"},
+	}
+
+	if value, ok := params.Get("ACME"); !ok || value != "2" {
+		t.Errorf("Get: wrong result: %q, %t", value, ok)
+	}
+	if _, ok := params.Get("charset"); ok {
+		t.Errorf("Get: unexpected match for charset")
+	}
+	if actual, expect := params.GetAll("acme"), []string{"2", "1"}; !reflect.DeepEqual(actual, expect) {
+		t.Errorf("GetAll: wrong result:\n\texpect: %q\n\tactual: %q", expect, actual)
+	}
+	if actual, expect := params.Map(), map[string]string{"boundary": "x", "acme": "2"}; !reflect.DeepEqual(actual, expect) {
+		t.Errorf("Map: wrong result:\n\texpect: %v\n\tactual: %v", expect, actual)
+	}
+
+	dupe := params.Clone()
+	dupe.Set("acme", "3")
+	expect := Params{{Name: "boundary", Value: "x"}, {Name: "acme", Value: "3"}}
+	if !reflect.DeepEqual(dupe, expect) {
+		t.Errorf("Set: wrong result:\n\texpect: %v\n\tactual: %v", expect, dupe)
+	}
+
+	dupe.Del("boundary")
+	dupe.Del("acme")
+	if dupe != nil {
+		t.Errorf("Del: wrong result: %v", dupe)
+	}
+
+	if params[1].Value != "2" {
+		t.Errorf("Clone: original was modified: %v", params)
+	}
+}
+
+func TestParamsFromMap(t *testing.T) {
+	actual := ParamsFromMap(map[string]string{"b": "2", "a": "1"})
+	expect := Params{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", expect, actual)
+	}
+}
+
+func TestIsMatchingParams(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Actual  Params
+		Pattern Params
+		Expect  bool
+	}
+
+	testData := [...]testCase{
+		{"Empty", nil, nil, true},
+		{"NoPattern", paramsCharset, nil, true},
+		{"Missing", nil, paramsCharset, false},
+		{"Equal", paramsCharset, paramsCharset, true},
+		{
+			Name:    "Duplicate",
+			Actual:  Params{{Name: "acme", Value: "1"}, {Name: "acme", Value: "2"}},
+			Pattern: Params{{Name: "acme", Value: "2"}},
+			Expect:  true,
+		},
+		{
+			Name:    "DuplicatePattern",
+			Actual:  Params{{Name: "acme", Value: "1"}},
+			Pattern: Params{{Name: "acme", Value: "1"}, {Name: "acme", Value: "2"}},
+			Expect:  false,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual := isMatchingParams(row.Actual, row.Pattern)
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %t\n\tactual: %t", row.Expect, actual)
+			}
+		})
+	}
+}
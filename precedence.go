@@ -0,0 +1,59 @@
+package acceptable
+
+import (
+	"sort"
+	"strings"
+)
+
+// ComparePrecedence orders media ranges by specificity, as described in RFC
+// 9110 section 12.5.1.  It returns a negative number if a is more specific
+// than b, a positive number if b is more specific than a, and zero if both
+// are equally specific.
+//
+// A concrete type is more specific than a partial wildcard such as "x-*",
+// which in turn is more specific than "*".  Type is considered before
+// subtype, and subtype before the number of parameters, so that:
+//
+//	text/html;level=1 > text/html > text/* > */*;level=1 > */*
+//
+// Unlike CompareTo, which defines the canonical order used by List.Sort, this
+// order is used by Negotiate to find the most specific range that matches an
+// offer.
+func (a Acceptable) ComparePrecedence(b Acceptable) int {
+	if cmp := compareUints(valueSpecificity(a.Value), valueSpecificity(b.Value)); cmp != 0 {
+		return -cmp
+	}
+	if cmp := compareUints(valueSpecificity(a.SubValue), valueSpecificity(b.SubValue)); cmp != 0 {
+		return -cmp
+	}
+	if cmp := compareUints(uint(len(a.Params)), uint(len(b.Params))); cmp != 0 {
+		return -cmp
+	}
+	return 0
+}
+
+// SortByPrecedence sorts the list from most to least specific, keeping
+// equally specific elements in their canonical order.
+func (list List) SortByPrecedence() {
+	sort.SliceStable(list, func(i, j int) bool {
+		return lessPrecedence(list[i], list[j])
+	})
+}
+
+func lessPrecedence(a, b Acceptable) bool {
+	if cmp := a.ComparePrecedence(b); cmp != 0 {
+		return cmp < 0
+	}
+	return a.CompareTo(b) < 0
+}
+
+func valueSpecificity(value string) uint {
+	switch {
+	case value == "*":
+		return 0
+	case strings.IndexByte(value, '*') >= 0:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,81 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAcceptable_ComparePrecedence(t *testing.T) {
+	type testCase struct {
+		Name   string
+		A      Acceptable
+		B      Acceptable
+		Expect int
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Equal",
+			A:      Acceptable{"text", "html", nil, 1000, nil},
+			B:      Acceptable{"text", "plain", nil, 500, nil},
+			Expect: 0,
+		},
+		{
+			Name:   "Params",
+			A:      Acceptable{"text", "html", paramsLevel, 1000, nil},
+			B:      Acceptable{"text", "html", nil, 1000, nil},
+			Expect: -1,
+		},
+		{
+			Name:   "SubValueBeforeParams",
+			A:      Acceptable{"text", "*", paramsLevel, 1000, nil},
+			B:      Acceptable{"text", "html", nil, 1000, nil},
+			Expect: 1,
+		},
+		{
+			Name:   "ValueBeforeParams",
+			A:      Acceptable{"*", "*", paramsLevel, 1000, nil},
+			B:      Acceptable{"text", "*", nil, 1000, nil},
+			Expect: 1,
+		},
+		{
+			Name:   "PartialWildcard",
+			A:      Acceptable{"text", "x-*", nil, 1000, nil},
+			B:      Acceptable{"text", "*", nil, 1000, nil},
+			Expect: -1,
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			if actual := row.A.ComparePrecedence(row.B); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %d\n\tactual: %d", row.Expect, actual)
+			}
+			if actual := row.B.ComparePrecedence(row.A); actual != -row.Expect {
+				t.Errorf("wrong reverse result:\n\texpect: %d\n\tactual: %d", -row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestList_SortByPrecedence(t *testing.T) {
+	list := List{
+		{"*", "*", nil, 500, nil},
+		{"*", "*", paramsLevel, 200, nil},
+		{"text", "*", nil, 300, nil},
+		{"text", "html", nil, 700, nil},
+		{"text", "html", paramsLevel, 1000, nil},
+	}
+	expect := List{
+		{"text", "html", paramsLevel, 1000, nil},
+		{"text", "html", nil, 700, nil},
+		{"text", "*", nil, 300, nil},
+		{"*", "*", paramsLevel, 200, nil},
+		{"*", "*", nil, 500, nil},
+	}
+
+	list.SortByPrecedence()
+	if !reflect.DeepEqual(list, expect) {
+		t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", expect, list)
+	}
+}
@@ -0,0 +1,166 @@
+package acceptable
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Sibling describes a precompressed variant of a file, stored next to the
+// original with the given suffix, such as "app.js.br" for "app.js".
+type Sibling struct {
+	Encoding string
+	Suffix   string
+	Quality  Quality
+}
+
+// DefaultSiblings lists the precompressed variants recognized by
+// PrecompressedHandler if its Siblings field is nil.
+var DefaultSiblings = []Sibling{
+	{"br", ".br", 1000},
+	{"zstd", ".zst", 900},
+	{"gzip", ".gz", 800},
+}
+
+// PrecompressedHandler serves files from FS, choosing among each file and its
+// precompressed siblings according to the request's Accept-Encoding field.
+//
+// The chosen variant is served with the Content-Encoding of its coding and
+// the Content-Type of the original file.  Every response carries
+// "Vary: Accept-Encoding".  A request without Accept-Encoding gets the
+// original file.  If no variant is acceptable, the response is 406 Not
+// Acceptable.
+type PrecompressedHandler struct {
+	FS       fs.FS
+	Siblings []Sibling
+}
+
+func (h PrecompressedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	fi, err := fs.Stat(h.FS, name)
+	if err != nil {
+		serveFSError(w, err)
+		return
+	}
+	if fi.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	siblings := h.Siblings
+	if siblings == nil {
+		siblings = DefaultSiblings
+	}
+
+	var available List
+	files := make(map[string]string, len(siblings))
+	for _, s := range siblings {
+		sfi, err := fs.Stat(h.FS, name+s.Suffix)
+		if err != nil || !sfi.Mode().IsRegular() {
+			continue
+		}
+		available = append(available, Acceptable{Value: s.Encoding, Quality: s.Quality})
+		files[CanonicalEncoding(s.Encoding)] = name + s.Suffix
+	}
+
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	field, err := ParseHeader(r.Header, "Accept-Encoding", ParseOptions{Mode: AbsentSubValue, Lenient: true, Limits: DefaultLimits})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// An absent field does not imply that the client can decode every
+	// coding (RFC 9110 section 12.5.3), so it gets the original file.
+	chosen := Acceptable{Value: "identity", Quality: MaxQuality}
+	if field.Present {
+		var ok bool
+		chosen, ok = NegotiateEncoding(available, field)
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			return
+		}
+	}
+
+	contentType, err := detectContentType(h.FS, name)
+	if err != nil {
+		serveFSError(w, err)
+		return
+	}
+
+	served := name
+	if file, found := files[CanonicalEncoding(chosen.Value)]; found {
+		served = file
+		w.Header().Set("Content-Encoding", chosen.Value)
+	}
+
+	f, err := h.FS.Open(served)
+	if err != nil {
+		serveFSError(w, err)
+		return
+	}
+	defer f.Close()
+
+	sfi, err := f.Stat()
+	if err != nil {
+		serveFSError(w, err)
+		return
+	}
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		raw, err := io.ReadAll(f)
+		if err != nil {
+			serveFSError(w, err)
+			return
+		}
+		content = bytes.NewReader(raw)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	http.ServeContent(w, r, name, sfi.ModTime(), content)
+}
+
+// detectContentType returns the Content-Type of the named file, guessing
+// from its extension or, failing that, its first 512 bytes.
+func detectContentType(fsys fs.FS, name string) (string, error) {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType, nil
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var buf [512]byte
+	n, err := io.ReadFull(f, buf[:])
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+func serveFSError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		code = http.StatusNotFound
+	case errors.Is(err, fs.ErrPermission):
+		code = http.StatusForbidden
+	}
+	http.Error(w, http.StatusText(code), code)
+}
+
+var _ http.Handler = PrecompressedHandler{}
@@ -0,0 +1,132 @@
+package acceptable
+
+import (
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestPrecompressedHandler(t *testing.T) {
+	type testCase struct {
+		Name           string
+		Path           string
+		Header         []string
+		ExpectCode     int
+		ExpectBody     string
+		ExpectEncoding string
+		ExpectType     string
+	}
+
+	fsys := fstest.MapFS{
+		"app.js":        {Data: []byte("console.log(1)")},
+		"app.js.br":     {Data: []byte("BR")},
+		"app.js.gz":     {Data: []byte("GZ")},
+		"app.js.zst":    {Data: []byte("ZST")},
+		"README":        {Data: []byte("hello, world")},
+		"README.gz":     {Data: []byte("GZ")},
+		"dir/index.css": {Data: []byte("body {}")},
+	}
+
+	jsType := mime.TypeByExtension(".js")
+	cssType := mime.TypeByExtension(".css")
+
+	testData := [...]testCase{
+		{
+			Name:       "Absent",
+			Path:       "/app.js",
+			ExpectCode: http.StatusOK,
+			ExpectBody: "console.log(1)",
+			ExpectType: jsType,
+		},
+		{
+			Name:           "Gzip",
+			Path:           "/app.js",
+			Header:         []string{"gzip"},
+			ExpectCode:     http.StatusOK,
+			ExpectBody:     "GZ",
+			ExpectEncoding: "gzip",
+			ExpectType:     jsType,
+		},
+		{
+			Name:           "ServerPreference",
+			Path:           "/app.js",
+			Header:         []string{"gzip, zstd"},
+			ExpectCode:     http.StatusOK,
+			ExpectBody:     "ZST",
+			ExpectEncoding: "zstd",
+			ExpectType:     jsType,
+		},
+		{
+			Name:       "Identity",
+			Path:       "/app.js",
+			Header:     []string{""},
+			ExpectCode: http.StatusOK,
+			ExpectBody: "console.log(1)",
+			ExpectType: jsType,
+		},
+		{
+			Name:       "Fallback",
+			Path:       "/dir/index.css",
+			Header:     []string{"br, gzip"},
+			ExpectCode: http.StatusOK,
+			ExpectBody: "body {}",
+			ExpectType: cssType,
+		},
+		{
+			Name:           "SniffedType",
+			Path:           "/README",
+			Header:         []string{"x-gzip"},
+			ExpectCode:     http.StatusOK,
+			ExpectBody:     "GZ",
+			ExpectEncoding: "gzip",
+			ExpectType:     "text/plain; charset=utf-8",
+		},
+		{
+			Name:       "NotAcceptable",
+			Path:       "/app.js",
+			Header:     []string{"deflate, identity;q=0"},
+			ExpectCode: http.StatusNotAcceptable,
+			ExpectBody: "Not Acceptable\n",
+			ExpectType: "text/plain; charset=utf-8",
+		},
+		{
+			Name:       "NotFound",
+			Path:       "/missing.js",
+			ExpectCode: http.StatusNotFound,
+			ExpectBody: "Not Found\n",
+			ExpectType: "text/plain; charset=utf-8",
+		},
+	}
+
+	handler := PrecompressedHandler{FS: fsys}
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, row.Path, nil)
+			for _, line := range row.Header {
+				r.Header.Add("Accept-Encoding", line)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != row.ExpectCode {
+				t.Errorf("wrong code:\n\texpect: %d\n\tactual: %d", row.ExpectCode, w.Code)
+			}
+			if actual := w.Body.String(); actual != row.ExpectBody {
+				t.Errorf("wrong body:\n\texpect: %q\n\tactual: %q", row.ExpectBody, actual)
+			}
+			if actual := w.Header().Get("Content-Encoding"); actual != row.ExpectEncoding {
+				t.Errorf("wrong Content-Encoding:\n\texpect: %q\n\tactual: %q", row.ExpectEncoding, actual)
+			}
+			if actual := w.Header().Get("Content-Type"); actual != row.ExpectType {
+				t.Errorf("wrong Content-Type:\n\texpect: %q\n\tactual: %q", row.ExpectType, actual)
+			}
+			if row.ExpectCode != http.StatusNotFound {
+				if actual := w.Header().Get("Vary"); actual != "Accept-Encoding" {
+					t.Errorf("wrong Vary:\n\texpect: %q\n\tactual: %q", "Accept-Encoding", actual)
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 const kDigits = "0123456789"
@@ -14,6 +15,8 @@ var (
 	reQuality  = regexp.MustCompile(`^(?:0|1|1\.0+|0?\.[0-9]+)$`)
 	reQuality0 = regexp.MustCompile(`^(?:0|0\.0+|\.0+)$`)
 	reQuality1 = regexp.MustCompile(`^1(?:\.0+)?$`)
+
+	reQualityStrict = regexp.MustCompile(`^(?:0(?:\.[0-9]{0,3})?|1(?:\.0{0,3})?)$`)
 )
 
 type Quality uint
@@ -61,8 +64,22 @@ func (q Quality) MarshalText() ([]byte, error) {
 }
 
 func (q *Quality) Parse(input string) error {
+	if err := q.parse(input, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *Quality) parse(input string, strict bool) *ParseError {
 	*q = 0
 
+	if strict {
+		if !reQualityStrict.MatchString(input) {
+			return newParseError(input, 0, ExpectQuality, input)
+		}
+		input = strings.TrimSuffix(input, ".")
+	}
+
 	if reQuality0.MatchString(input) {
 		return nil
 	}
@@ -73,7 +90,7 @@ func (q *Quality) Parse(input string) error {
 	}
 
 	if !reQuality.MatchString(input) {
-		return fmt.Errorf("invalid quality %q", input)
+		return newParseError(input, 0, ExpectQuality, input)
 	}
 
 	f64, err := strconv.ParseFloat(input, 32)
@@ -1,7 +1,6 @@
 package acceptable
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -38,6 +37,10 @@ func TestQuality_String(t *testing.T) {
 	}
 }
 
+func qualityError(input string) error {
+	return &ParseError{input, 0, 0, ExpectQuality, input, ErrInvalidQuality}
+}
+
 func TestQuality_Parse(t *testing.T) {
 	type testCase struct {
 		Input  string
@@ -70,11 +73,11 @@ func TestQuality_Parse(t *testing.T) {
 		{".990", 990, nil},
 		{".999", 999, nil},
 
-		{"", 0, errors.New("invalid quality \"\"")},
-		{"2", 0, errors.New("invalid quality \"2\"")},
-		{"0.", 0, errors.New("invalid quality \"0.\"")},
-		{"1.", 0, errors.New("invalid quality \"1.\"")},
-		{"1.1", 0, errors.New("invalid quality \"1.1\"")},
+		{"", 0, qualityError("")},
+		{"2", 0, qualityError("2")},
+		{"0.", 0, qualityError("0.")},
+		{"1.", 0, qualityError("1.")},
+		{"1.1", 0, qualityError("1.1")},
 	}
 
 	for _, row := range testData {
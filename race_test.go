@@ -0,0 +1,7 @@
+//go:build race
+
+package acceptable
+
+// The race detector changes escape analysis, so allocation counts are not
+// meaningful under it.
+const raceEnabled = true
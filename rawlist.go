@@ -0,0 +1,127 @@
+package acceptable
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// RawList is a List that remembers the original text of each element, and
+// of the separator before it, so that elements left unmodified are
+// serialized byte-for-byte as they were received.  Only elements that have
+// been changed, or added, are re-rendered.
+//
+// Elements are matched with their original text by position, so the text
+// of the element at index i is used only while List[i] still equals the
+// element originally parsed there.
+type RawList struct {
+	List List
+	raw  []rawText
+}
+
+type rawText struct {
+	text string
+	sep  string
+	orig Acceptable
+}
+
+// Parse parses input as a List, remembering the original text of each
+// element.  Warnings and errors are reported as for List.ParseWithOptions.
+func (rl *RawList) Parse(input string, opts ParseOptions) ([]*ParseError, error) {
+	*rl = RawList{}
+
+	var result RawList
+	warnings, err := parseList(input, &opts, func(e Element) {
+		a := e.Acceptable()
+		orig := a
+		orig.Params = orig.Params.Clone()
+		orig.Extensions = orig.Extensions.Clone()
+		result.List = append(result.List, a)
+		result.raw = append(result.raw, rawText{text: e.text, sep: e.sep, orig: orig})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	*rl = result
+	return warnings, nil
+}
+
+// Raw returns the original text of the element at index i, if it has not
+// been modified since it was parsed.
+func (rl RawList) Raw(i int) (string, bool) {
+	if raw := rl.rawAt(i); raw != nil {
+		return raw.text, true
+	}
+	return "", false
+}
+
+func (rl RawList) rawAt(i int) *rawText {
+	if i < 0 || i >= len(rl.raw) || i >= len(rl.List) {
+		return nil
+	}
+	raw := &rl.raw[i]
+	if !isIdentical(rl.List[i], raw.orig) {
+		return nil
+	}
+	return raw
+}
+
+func (rl RawList) Append(out []byte) []byte {
+	out, _ = rl.AppendWithOptions(out, FormatOptions{})
+	return out
+}
+
+// AppendWithOptions is like List.AppendWithOptions, but re-emits the
+// original text of unmodified elements when it complies with opts.
+func (rl RawList) AppendWithOptions(out []byte, opts FormatOptions) ([]byte, error) {
+	var err error
+	for i, a := range rl.List {
+		raw := rl.rawAt(i)
+		if i > 0 {
+			if raw != nil && raw.sep != "" {
+				out = append(out, raw.sep...)
+			} else {
+				out = append(out, ", "...)
+			}
+		}
+		if raw != nil && isRawAllowed(raw.text, opts.ObsText) {
+			out = append(out, raw.text...)
+			continue
+		}
+		out, err = a.AppendWithOptions(out, opts)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// isRawAllowed reports whether text may be re-emitted verbatim under policy.
+// ObsTextDefault avoids generating obs-text, so text containing it is
+// re-rendered instead.
+func isRawAllowed(text string, policy ObsTextPolicy) bool {
+	if policy == ObsTextDefault && hasObsText(text) {
+		return false
+	}
+	_, err := checkObsText(text, policy)
+	return err == nil
+}
+
+func (rl RawList) String() string {
+	return string(rl.Append(nil))
+}
+
+func (rl RawList) MarshalText() ([]byte, error) {
+	return rl.Append(nil), nil
+}
+
+func (rl *RawList) UnmarshalText(input []byte) error {
+	_, err := rl.Parse(string(input), ParseOptions{})
+	return err
+}
+
+var (
+	_ fmt.Stringer             = RawList{}
+	_ encoding.TextMarshaler   = RawList{}
+	_ encoding.TextUnmarshaler = (*RawList)(nil)
+)
@@ -0,0 +1,97 @@
+package acceptable
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ParamRule compares two values of the same parameter, returning a negative
+// number, zero, or a positive number like strings.Compare.  Two values match
+// during negotiation if and only if the rule returns zero.
+type ParamRule func(a, b string) int
+
+var (
+	ExactRule           ParamRule = compareStrings
+	CaseInsensitiveRule ParamRule = compareFolded
+	NumericRule         ParamRule = compareNumeric
+)
+
+var (
+	gRuleMutex sync.RWMutex
+	gRules     = map[string]ParamRule{
+		"charset": CaseInsensitiveRule,
+		"format":  CaseInsensitiveRule,
+		"delsp":   CaseInsensitiveRule,
+		"level":   NumericRule,
+	}
+)
+
+// RegisterParamRule sets the rule used to compare values of the named
+// parameter.  Passing a nil rule restores the default, ExactRule.
+func RegisterParamRule(name string, rule ParamRule) {
+	name = strings.ToLower(name)
+
+	gRuleMutex.Lock()
+	defer gRuleMutex.Unlock()
+
+	if rule == nil {
+		delete(gRules, name)
+		return
+	}
+	gRules[name] = rule
+}
+
+// LookupParamRule returns the rule used to compare values of the named
+// parameter.
+func LookupParamRule(name string) ParamRule {
+	gRuleMutex.RLock()
+	rule, found := gRules[name]
+	if !found {
+		rule, found = gRules[strings.ToLower(name)]
+	}
+	gRuleMutex.RUnlock()
+
+	if !found {
+		return ExactRule
+	}
+	return rule
+}
+
+func compareParamValues(name, a, b string) int {
+	if a == b {
+		return 0
+	}
+	return LookupParamRule(name)(a, b)
+}
+
+func compareFolded(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		x, y := toLower(a[i]), toLower(b[i])
+		if x != y {
+			return compareUints(uint(x), uint(y))
+		}
+	}
+	return compareUints(uint(len(a)), uint(len(b)))
+}
+
+func compareNumeric(a, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX != nil || errY != nil || math.IsNaN(x) || math.IsNaN(y) {
+		return compareStrings(a, b)
+	}
+	return compareFloats(x, y)
+}
+
+func toLower(ch byte) byte {
+	if isUpper(ch) {
+		ch += 'a' - 'A'
+	}
+	return ch
+}
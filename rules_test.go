@@ -0,0 +1,71 @@
+package acceptable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParamRules(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Param  string
+		A      string
+		B      string
+		Expect int
+	}
+
+	testData := [...]testCase{
+		{"CharsetFold", "charset", "utf-8", "UTF-8", 0},
+		{"CharsetOrder", "charset", "ISO-8859-1", "utf-8", -1},
+		{"CharsetUpperName", "Charset", "utf-8", "UTF-8", 0},
+		{"LevelNumeric", "level", "1", "1.0", 0},
+		{"LevelOrder", "level", "10", "9", 1},
+		{"LevelFallback", "level", "one", "1", 1},
+		{"DefaultExact", "boundary", "abc", "ABC", 1},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual := compareParamValues(row.Param, row.A, row.B)
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %d\n\tactual: %d", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestRegisterParamRule(t *testing.T) {
+	const name = "x-test-rule"
+	defer RegisterParamRule(name, nil)
+
+	RegisterParamRule(name, func(a, b string) int {
+		return compareStrings(strings.TrimSpace(a), strings.TrimSpace(b))
+	})
+
+	a := Acceptable{"text", "plain", Params{{Name: name, Value: "a"}}, 1000, nil}
+	b := Acceptable{"text", "plain", Params{{Name: name, Value: " a "}}, 1000, nil}
+	if !a.EqualTo(b) {
+		t.Errorf("custom rule not used by CompareTo")
+	}
+
+	RegisterParamRule(name, nil)
+	if a.EqualTo(b) {
+		t.Errorf("custom rule not removed")
+	}
+}
+
+func TestNegotiate_ParamRules(t *testing.T) {
+	available := List{
+		{"text", "plain", Params{{Name: "charset", Value: "UTF-8"}}, 1000, nil},
+		{"text", "html", nil, 1000, nil},
+	}
+	preferences := List{
+		{"text", "plain", paramsCharset, 1000, nil},
+		{"text", "html", nil, 100, nil},
+	}
+
+	actual, ok := Negotiate(available, preferences)
+	if !ok || !actual.EqualTo(available[0]) {
+		t.Errorf("wrong result: %v, %t", actual, ok)
+	}
+}
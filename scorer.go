@@ -0,0 +1,76 @@
+package acceptable
+
+import (
+	"context"
+	"math"
+)
+
+// ScoreInput is everything a Scorer knows about one offer.
+type ScoreInput struct {
+	// Offer is the available element being scored, and Index is its
+	// position in Available.  Offer.Quality is the server's source quality
+	// for the offer, in the sense of Apache's "qs".
+	Offer Acceptable
+	Index int
+
+	// Preference is the client preference that matched Offer, and
+	// PreferenceIndex is its position in Preferences.  If Preferences is
+	// empty, PreferenceIndex is -1.
+	Preference      Acceptable
+	PreferenceIndex int
+
+	// Weight is the factor applied for how well Preference matched Offer:
+	// 1 for an exact match, or the Negotiator's SuffixQuality for a match
+	// by structured syntax suffix.
+	Weight float64
+
+	// Available and Preferences are the lists being negotiated.
+	Available   List
+	Preferences List
+
+	// Context carries request-scoped values, such as the *http.Request or
+	// a deadline, to the Scorer.  It is the context passed to ChooseContext
+	// or NegotiateAllContext, or context.Background() otherwise.
+	Context context.Context
+}
+
+// Scorer computes the score by which an offer is ranked.  It is consulted
+// only for offers that some preference matched, or for every offer if there
+// are no preferences.  An offer whose score is not positive is not
+// acceptable.
+type Scorer interface {
+	Score(in ScoreInput) float64
+}
+
+// ScorerFunc adapts a function to the Scorer interface.
+type ScorerFunc func(in ScoreInput) float64
+
+func (fn ScorerFunc) Score(in ScoreInput) float64 {
+	return fn(in)
+}
+
+// ProductScorer is the default Scorer.  It multiplies the server's quality,
+// the client's quality and the match weight.
+var ProductScorer Scorer = ScorerFunc(productScore)
+
+func productScore(in ScoreInput) float64 {
+	score := float64(in.Offer.Quality) / 1000
+	if in.PreferenceIndex >= 0 {
+		score *= float64(in.Preference.Quality) / 1000
+	}
+	return score * in.Weight
+}
+
+func (n Negotiator) score(in ScoreInput) float64 {
+	scorer := n.Scorer
+	if scorer == nil {
+		scorer = ProductScorer
+	}
+	score := scorer.Score(in)
+	if math.IsNaN(score) {
+		return 0
+	}
+	return score
+}
+
+var _ Scorer = ScorerFunc(nil)
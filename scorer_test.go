@@ -0,0 +1,114 @@
+package acceptable
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNegotiator_Scorer(t *testing.T) {
+	webp := Acceptable{"image", "webp", nil, 1000, nil}
+	jpeg := Acceptable{"image", "jpeg", nil, 1000, nil}
+	png := Acceptable{"image", "png", nil, 900, nil}
+	available := List{webp, jpeg, png}
+	preferences := List{{"image", "*", nil, 800, nil}}
+
+	actual, _ := Negotiate(available, preferences)
+	if !reflect.DeepEqual(actual, jpeg) {
+		t.Errorf("wrong default result:\n\texpect: %v\n\tactual: %v", jpeg, actual)
+	}
+
+	var inputs []ScoreInput
+	lossy := Negotiator{
+		Scorer: ScorerFunc(func(in ScoreInput) float64 {
+			inputs = append(inputs, in)
+			score := ProductScorer.Score(in)
+			if in.Offer.SubValue == "jpeg" {
+				score *= 0.5
+			}
+			return score
+		}),
+	}
+
+	actual, _ = lossy.Negotiate(available, preferences)
+	if !reflect.DeepEqual(actual, webp) {
+		t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", webp, actual)
+	}
+
+	expectInput := ScoreInput{
+		Offer:           jpeg,
+		Index:           1,
+		Preference:      preferences[0],
+		PreferenceIndex: 0,
+		Weight:          1,
+		Available:       available,
+		Preferences:     preferences,
+		Context:         context.Background(),
+	}
+	if len(inputs) != 3 || !reflect.DeepEqual(inputs[1], expectInput) {
+		t.Errorf("wrong inputs:\n\texpect: [_ %+v _]\n\tactual: %+v", expectInput, inputs)
+	}
+}
+
+func TestNegotiator_ScorerContext(t *testing.T) {
+	type ctxKey struct{}
+
+	small := Acceptable{"image", "avif", nil, 900, nil}
+	large := Acceptable{"image", "png", nil, 1000, nil}
+	available := List{large, small}
+	preferences := List{{"image", "*", nil, 1000, nil}}
+
+	n := Negotiator{
+		Scorer: ScorerFunc(func(in ScoreInput) float64 {
+			score := ProductScorer.Score(in)
+			if saveData, _ := in.Context.Value(ctxKey{}).(bool); saveData && in.Offer.SubValue == "png" {
+				score *= 0.5
+			}
+			return score
+		}),
+	}
+
+	r, ok := n.Choose(available, preferences)
+	if !ok || !reflect.DeepEqual(r.Offer, large) {
+		t.Errorf("wrong default result:\n\texpect: %v, true\n\tactual: %v, %t", large, r.Offer, ok)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	r, ok = n.ChooseContext(ctx, available, preferences)
+	if !ok || !reflect.DeepEqual(r.Offer, small) {
+		t.Errorf("wrong result:\n\texpect: %v, true\n\tactual: %v, %t", small, r.Offer, ok)
+	}
+}
+
+func TestNegotiator_ScorerNoPreferences(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Score  float64
+		Expect bool
+	}
+
+	testData := [...]testCase{
+		{"Positive", 0.25, true},
+		{"Zero", 0, false},
+		{"Negative", -1, false},
+		{"NaN", math.NaN(), false},
+	}
+
+	available := List{{"text", "html", nil, 1000, nil}}
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			n := Negotiator{
+				Scorer: ScorerFunc(func(in ScoreInput) float64 {
+					if in.PreferenceIndex != -1 || in.Weight != 1 {
+						t.Errorf("wrong input: %+v", in)
+					}
+					return row.Score
+				}),
+			}
+			if _, ok := n.Negotiate(available, nil); ok != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %t\n\tactual: %t", row.Expect, ok)
+			}
+		})
+	}
+}
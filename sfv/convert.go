@@ -0,0 +1,174 @@
+package sfv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	acceptable "github.com/chronos-tachyon/go-acceptable"
+)
+
+// FromAcceptable converts a legacy Accept-style element into an Item.
+//
+// The value becomes a Token such as "text/html" (or a String, if it is not a
+// valid Token), the media type parameters become Item parameters, and the
+// weight and any accept-extensions follow as a Decimal "q" parameter and
+// further parameters, in that order.
+func FromAcceptable(a acceptable.Acceptable) (Item, error) {
+	value := a.Value
+	if a.SubValue != "" {
+		value += "/" + a.SubValue
+	}
+
+	item := Item{Value: stringOrToken(value)}
+	if err := appendAcceptableParams(&item.Params, a.Params); err != nil {
+		return Item{}, err
+	}
+	if a.Quality < acceptable.MaxQuality || len(a.Extensions) > 0 {
+		item.Params = append(item.Params, Param{Key: "q", Value: Decimal(a.Quality)})
+	}
+	if err := appendAcceptableParams(&item.Params, a.Extensions); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// ToAcceptable converts an Item whose value is a Token or String into a
+// legacy Accept-style element.  A "q" parameter, if present, must be an
+// Integer or Decimal between 0 and 1.
+func ToAcceptable(item Item, mode acceptable.SubValueMode) (acceptable.Acceptable, error) {
+	var a acceptable.Acceptable
+
+	var str string
+	switch x := item.Value.(type) {
+	case Token:
+		str = string(x)
+	case string:
+		str = x
+	default:
+		return a, fmt.Errorf("%w: cannot convert %T to Acceptable", ErrInvalidValue, item.Value)
+	}
+
+	a.Value = str
+	if mode != acceptable.AbsentSubValue {
+		if i := strings.IndexByte(str, '/'); i >= 0 {
+			a.Value, a.SubValue = str[:i], str[i+1:]
+		} else if mode == acceptable.RequiredSubValue {
+			return a, fmt.Errorf("%w: %q has no subtype", ErrInvalidValue, str)
+		}
+	}
+
+	a.Quality = acceptable.MaxQuality
+	hasQ := false
+	for _, p := range item.Params {
+		if p.Key == "q" && !hasQ {
+			q, err := toQuality(p.Value)
+			if err != nil {
+				return acceptable.Acceptable{}, err
+			}
+			a.Quality = q
+			hasQ = true
+			continue
+		}
+
+		value, err := toParamValue(p.Value)
+		if err != nil {
+			return acceptable.Acceptable{}, err
+		}
+		if hasQ {
+			a.Extensions.Add(p.Key, value)
+		} else {
+			a.Params.Add(p.Key, value)
+		}
+	}
+	return a, nil
+}
+
+func FromList(list acceptable.List) (List, error) {
+	if len(list) <= 0 {
+		return nil, nil
+	}
+	out := make(List, 0, len(list))
+	for _, a := range list {
+		item, err := FromAcceptable(a)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func ToList(list List, mode acceptable.SubValueMode) (acceptable.List, error) {
+	if len(list) <= 0 {
+		return nil, nil
+	}
+	out := make(acceptable.List, 0, len(list))
+	for _, member := range list {
+		item, ok := member.(Item)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot convert %T to Acceptable", ErrInvalidValue, member)
+		}
+		a, err := ToAcceptable(item, mode)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func appendAcceptableParams(out *Params, params acceptable.Params) error {
+	for _, p := range params {
+		key := strings.ToLower(p.Name)
+		if !isValidKey(key) {
+			return fmt.Errorf("%w: invalid key %q", ErrInvalidValue, p.Name)
+		}
+		if p.Lang != "" || !isValidString(p.Value) {
+			return fmt.Errorf("%w: parameter %q is not ASCII", ErrInvalidValue, p.Name)
+		}
+		*out = append(*out, Param{Key: key, Value: stringOrToken(p.Value)})
+	}
+	return nil
+}
+
+func stringOrToken(str string) any {
+	if isValidToken(str) {
+		return Token(str)
+	}
+	return str
+}
+
+func toQuality(value any) (acceptable.Quality, error) {
+	var d Decimal
+	switch x := value.(type) {
+	case Decimal:
+		d = x
+	case int64:
+		if x < 0 || x > 1 {
+			return 0, fmt.Errorf("%w: quality %d out of range", ErrInvalidValue, x)
+		}
+		d = Decimal(x * 1000)
+	default:
+		return 0, fmt.Errorf("%w: cannot convert %T to Quality", ErrInvalidValue, value)
+	}
+	if d < acceptable.MinQuality || d > acceptable.MaxQuality {
+		return 0, fmt.Errorf("%w: quality %v out of range", ErrInvalidValue, d)
+	}
+	return acceptable.Quality(d), nil
+}
+
+func toParamValue(value any) (string, error) {
+	switch x := value.(type) {
+	case Token:
+		return string(x), nil
+	case string:
+		return x, nil
+	case int64:
+		return strconv.FormatInt(x, 10), nil
+	case Decimal:
+		return x.String(), nil
+	default:
+		return "", fmt.Errorf("%w: cannot convert %T to a parameter value", ErrInvalidValue, value)
+	}
+}
@@ -0,0 +1,96 @@
+package sfv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	acceptable "github.com/chronos-tachyon/go-acceptable"
+)
+
+func TestFromList(t *testing.T) {
+	var input acceptable.List
+	if err := input.Parse(`text/html;level=1;q=0.5;foo="a b", */*;q=0.1, application/json`, acceptable.OptionalSubValue); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := FromList(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := list.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const expect = `text/html;level="1";q=0.5;foo="a b", */*;q=0.1, application/json`
+	if actual := string(out); actual != expect {
+		t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", expect, actual)
+	}
+
+	back, err := ToList(list, acceptable.OptionalSubValue)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(back, input) {
+		t.Errorf("wrong round trip:\n\texpect: %v\n\tactual: %v", input, back)
+	}
+}
+
+func TestToAcceptable(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Mode   acceptable.SubValueMode
+		Expect acceptable.Acceptable
+		Fail   bool
+	}
+
+	testData := [...]testCase{
+		{
+			Name:  "Token",
+			Input: "gzip;q=1",
+			Mode:  acceptable.AbsentSubValue,
+			Expect: acceptable.Acceptable{
+				Value:   "gzip",
+				Quality: 1000,
+			},
+		},
+		{
+			Name:  "String",
+			Input: `"text/plain";charset=utf-8;q=0.25;n=3`,
+			Expect: acceptable.Acceptable{
+				Value:      "text",
+				SubValue:   "plain",
+				Params:     acceptable.Params{{Name: "charset", Value: "utf-8"}},
+				Quality:    250,
+				Extensions: acceptable.Params{{Name: "n", Value: "3"}},
+			},
+		},
+		{Name: "FailInteger", Input: "1", Fail: true},
+		{Name: "FailQuality", Input: "a/b;q=2", Fail: true},
+		{Name: "FailRequired", Input: "a", Mode: acceptable.RequiredSubValue, Fail: true},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			item, err := ParseItem(row.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := ToAcceptable(item, row.Mode)
+			if row.Fail {
+				if !errors.Is(err, ErrInvalidValue) {
+					t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", ErrInvalidValue, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v\n\tactual: %#v", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package sfv
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+func ParseItem(input string) (Item, error) {
+	p := parser{input: input}
+	p.skipSP()
+	item, err := p.parseItem()
+	if err == nil {
+		err = p.finish()
+	}
+	if err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+func ParseList(input string) (List, error) {
+	p := parser{input: input}
+	p.skipSP()
+	list, err := p.parseList()
+	if err == nil {
+		err = p.finish()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func ParseDictionary(input string) (Dictionary, error) {
+	p := parser{input: input}
+	p.skipSP()
+	dict, err := p.parseDictionary()
+	if err == nil {
+		err = p.finish()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+func (item *Item) UnmarshalText(input []byte) error {
+	x, err := ParseItem(string(input))
+	*item = x
+	return err
+}
+
+func (list *List) UnmarshalText(input []byte) error {
+	x, err := ParseList(string(input))
+	*list = x
+	return err
+}
+
+func (dict *Dictionary) UnmarshalText(input []byte) error {
+	x, err := ParseDictionary(string(input))
+	*dict = x
+	return err
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) fail(msg string) *ParseError {
+	return &ParseError{Input: p.input, Offset: p.pos, Msg: msg}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSP() {
+	for !p.eof() && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) skipOWS() {
+	for !p.eof() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) finish() error {
+	p.skipSP()
+	if !p.eof() {
+		return p.fail("unexpected trailing characters")
+	}
+	return nil
+}
+
+func (p *parser) parseList() (List, error) {
+	var list List
+	for !p.eof() {
+		member, err := p.parseMember()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, member)
+
+		p.skipOWS()
+		if p.eof() {
+			return list, nil
+		}
+		if p.peek() != ',' {
+			return nil, p.fail("expected ','")
+		}
+		p.pos++
+		p.skipOWS()
+		if p.eof() {
+			return nil, p.fail("trailing ','")
+		}
+	}
+	return list, nil
+}
+
+func (p *parser) parseDictionary() (Dictionary, error) {
+	var dict Dictionary
+	for !p.eof() {
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+
+		var member Member
+		if p.peek() == '=' {
+			p.pos++
+			member, err = p.parseMember()
+		} else {
+			var params Params
+			params, err = p.parseParams()
+			member = Item{Value: true, Params: params}
+		}
+		if err != nil {
+			return nil, err
+		}
+		dict.Set(key, member)
+
+		p.skipOWS()
+		if p.eof() {
+			return dict, nil
+		}
+		if p.peek() != ',' {
+			return nil, p.fail("expected ','")
+		}
+		p.pos++
+		p.skipOWS()
+		if p.eof() {
+			return nil, p.fail("trailing ','")
+		}
+	}
+	return dict, nil
+}
+
+func (p *parser) parseMember() (Member, error) {
+	if p.peek() == '(' {
+		return p.parseInnerList()
+	}
+	return p.parseItem()
+}
+
+func (p *parser) parseInnerList() (InnerList, error) {
+	if p.peek() != '(' {
+		return InnerList{}, p.fail("expected '('")
+	}
+	p.pos++
+
+	var items []Item
+	for !p.eof() {
+		p.skipSP()
+		if p.peek() == ')' {
+			p.pos++
+			params, err := p.parseParams()
+			if err != nil {
+				return InnerList{}, err
+			}
+			return InnerList{Items: items, Params: params}, nil
+		}
+
+		item, err := p.parseItem()
+		if err != nil {
+			return InnerList{}, err
+		}
+		items = append(items, item)
+
+		if ch := p.peek(); ch != ' ' && ch != ')' {
+			return InnerList{}, p.fail("expected ' ' or ')'")
+		}
+	}
+	return InnerList{}, p.fail("unterminated inner list")
+}
+
+func (p *parser) parseItem() (Item, error) {
+	value, err := p.parseBareItem()
+	if err != nil {
+		return Item{}, err
+	}
+	params, err := p.parseParams()
+	if err != nil {
+		return Item{}, err
+	}
+	return Item{Value: value, Params: params}, nil
+}
+
+func (p *parser) parseParams() (Params, error) {
+	var params Params
+	for p.peek() == ';' {
+		p.pos++
+		p.skipSP()
+
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+
+		var value any = true
+		if p.peek() == '=' {
+			p.pos++
+			value, err = p.parseBareItem()
+			if err != nil {
+				return nil, err
+			}
+		}
+		params.Set(key, value)
+	}
+	return params, nil
+}
+
+func (p *parser) parseKey() (string, error) {
+	if ch := p.peek(); !isLCAlpha(ch) && ch != '*' {
+		return "", p.fail("expected key")
+	}
+	start := p.pos
+	for !p.eof() && isKeyChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *parser) parseBareItem() (any, error) {
+	ch := p.peek()
+	switch {
+	case ch == '-' || isDigit(ch):
+		return p.parseNumber()
+	case ch == '"':
+		return p.parseString()
+	case ch == '*' || isAlpha(ch):
+		return p.parseToken()
+	case ch == ':':
+		return p.parseByteSequence()
+	case ch == '?':
+		return p.parseBoolean()
+	default:
+		return nil, p.fail("expected bare item")
+	}
+}
+
+func (p *parser) parseNumber() (any, error) {
+	negative := false
+	if p.peek() == '-' {
+		negative = true
+		p.pos++
+	}
+	if !isDigit(p.peek()) {
+		return nil, p.fail("expected digit")
+	}
+
+	start := p.pos
+	var intPart, fracPart int64
+	var fracDigits int
+	isDecimal := false
+loop:
+	for !p.eof() {
+		ch := p.input[p.pos]
+		switch {
+		case isDigit(ch) && !isDecimal:
+			intPart = intPart*10 + int64(ch-'0')
+		case isDigit(ch):
+			fracPart = fracPart*10 + int64(ch-'0')
+			fracDigits++
+		case ch == '.' && !isDecimal:
+			if p.pos-start > 12 {
+				return nil, p.fail("decimal has too many integer digits")
+			}
+			isDecimal = true
+		default:
+			break loop
+		}
+		p.pos++
+
+		if !isDecimal && p.pos-start > 15 {
+			return nil, p.fail("integer has too many digits")
+		}
+		if isDecimal && p.pos-start > 16 {
+			return nil, p.fail("decimal has too many digits")
+		}
+	}
+
+	if !isDecimal {
+		if negative {
+			intPart = -intPart
+		}
+		return intPart, nil
+	}
+
+	if fracDigits == 0 {
+		return nil, p.fail("decimal ends with '.'")
+	}
+	if fracDigits > 3 {
+		return nil, p.fail("decimal has too many fractional digits")
+	}
+	for i := fracDigits; i < 3; i++ {
+		fracPart *= 10
+	}
+	d := Decimal(intPart*1000 + fracPart)
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+func (p *parser) parseString() (string, error) {
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		ch := p.input[p.pos]
+		p.pos++
+		switch {
+		case ch == '\\':
+			if p.eof() {
+				return "", p.fail("unterminated escape")
+			}
+			next := p.input[p.pos]
+			if next != '"' && next != '\\' {
+				return "", p.fail("invalid escape")
+			}
+			sb.WriteByte(next)
+			p.pos++
+		case ch == '"':
+			return sb.String(), nil
+		case ch < 0x20 || ch > 0x7e:
+			p.pos--
+			return "", p.fail("invalid string character")
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", p.fail("unterminated string")
+}
+
+func (p *parser) parseToken() (Token, error) {
+	start := p.pos
+	p.pos++
+	for !p.eof() && isTokenChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return Token(p.input[start:p.pos]), nil
+}
+
+func (p *parser) parseByteSequence() (ByteSequence, error) {
+	p.pos++
+	end := strings.IndexByte(p.input[p.pos:], ':')
+	if end < 0 {
+		return nil, p.fail("unterminated byte sequence")
+	}
+	encoded := p.input[p.pos : p.pos+end]
+	for i := 0; i < len(encoded); i++ {
+		if !isBase64Char(encoded[i]) {
+			p.pos += i
+			return nil, p.fail("invalid byte sequence character")
+		}
+	}
+	// Padding is optional when parsing (RFC 8941 section 4.2.7).
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, p.fail("invalid base64")
+	}
+	p.pos += end + 1
+	return ByteSequence(decoded), nil
+}
+
+func (p *parser) parseBoolean() (bool, error) {
+	p.pos++
+	switch p.peek() {
+	case '0':
+		p.pos++
+		return false, nil
+	case '1':
+		p.pos++
+		return true, nil
+	default:
+		return false, p.fail("expected '0' or '1'")
+	}
+}
+
+func isDigit(ch byte) bool   { return ch >= '0' && ch <= '9' }
+func isLCAlpha(ch byte) bool { return ch >= 'a' && ch <= 'z' }
+func isAlpha(ch byte) bool   { return isLCAlpha(ch) || (ch >= 'A' && ch <= 'Z') }
+
+func isKeyChar(ch byte) bool {
+	return isLCAlpha(ch) || isDigit(ch) || ch == '_' || ch == '-' || ch == '.' || ch == '*'
+}
+
+func isTokenChar(ch byte) bool {
+	const SET = "!#$%&'*+-.^_`|~:/"
+	return isAlpha(ch) || isDigit(ch) || strings.IndexByte(SET, ch) >= 0
+}
+
+func isBase64Char(ch byte) bool {
+	return isAlpha(ch) || isDigit(ch) || ch == '+' || ch == '/' || ch == '='
+}
@@ -0,0 +1,218 @@
+package sfv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseItem(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect Item
+		Fail   bool
+	}
+
+	testData := [...]testCase{
+		{Name: "Integer", Input: "42", Expect: Item{Value: int64(42)}},
+		{Name: "NegativeInteger", Input: "-42", Expect: Item{Value: int64(-42)}},
+		{Name: "MaxInteger", Input: "999999999999999", Expect: Item{Value: int64(MaxInteger)}},
+		{Name: "Decimal", Input: "4.5", Expect: Item{Value: Decimal(4500)}},
+		{Name: "NegativeDecimal", Input: "-0.125", Expect: Item{Value: Decimal(-125)}},
+		{Name: "String", Input: `"hello \"world\""`, Expect: Item{Value: `hello "world"`}},
+		{Name: "Token", Input: "text/html", Expect: Item{Value: Token("text/html")}},
+		{Name: "StarToken", Input: "*/*", Expect: Item{Value: Token("*/*")}},
+		{Name: "ByteSequence", Input: ":cHJldGVuZCB0aGlzIGlzIGJpbmFyeSBjb250ZW50Lg==:", Expect: Item{Value: ByteSequence("pretend this is binary content.")}},
+		{Name: "ByteSequenceUnpadded", Input: ":cHJldGVuZCB0aGlzIGlzIGJpbmFyeSBjb250ZW50Lg:", Expect: Item{Value: ByteSequence("pretend this is binary content.")}},
+		{Name: "True", Input: "?1", Expect: Item{Value: true}},
+		{Name: "False", Input: "?0", Expect: Item{Value: false}},
+		{
+			Name:  "Params",
+			Input: "  abc;a=1;b=2;cde_456 ",
+			Expect: Item{Value: Token("abc"), Params: Params{
+				{Key: "a", Value: int64(1)},
+				{Key: "b", Value: int64(2)},
+				{Key: "cde_456", Value: true},
+			}},
+		},
+		{
+			Name:  "DuplicateParams",
+			Input: "1;a=1;b=2;a=3",
+			Expect: Item{Value: int64(1), Params: Params{
+				{Key: "a", Value: int64(3)},
+				{Key: "b", Value: int64(2)},
+			}},
+		},
+
+		{Name: "FailEmpty", Input: "", Fail: true},
+		{Name: "FailLongInteger", Input: "9999999999999999", Fail: true},
+		{Name: "FailLongDecimal", Input: "1234567890123.0", Fail: true},
+		{Name: "FailFraction", Input: "1.2345", Fail: true},
+		{Name: "FailTrailingDot", Input: "1.", Fail: true},
+		{Name: "FailEscape", Input: `"\a"`, Fail: true},
+		{Name: "FailNonASCII", Input: "\"caf\xc3\xa9\"", Fail: true},
+		{Name: "FailUpperKey", Input: "1;A=1", Fail: true},
+		{Name: "FailBoolean", Input: "?2", Fail: true},
+		{Name: "FailBase64", Input: ":!!:", Fail: true},
+		{Name: "FailTrailing", Input: "1 2", Fail: true},
+		{Name: "FailTab", Input: "\t1", Fail: true},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, err := ParseItem(row.Input)
+			if row.Fail {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", ErrSyntax, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v\n\tactual: %#v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect List
+		Fail   bool
+	}
+
+	testData := [...]testCase{
+		{Name: "Empty", Input: ""},
+		{
+			Name:  "Tokens",
+			Input: "sugar, tea,\trum",
+			Expect: List{
+				Item{Value: Token("sugar")},
+				Item{Value: Token("tea")},
+				Item{Value: Token("rum")},
+			},
+		},
+		{
+			Name:  "InnerLists",
+			Input: `("foo" "bar");lvl=5, ("baz"), ()`,
+			Expect: List{
+				InnerList{Items: []Item{{Value: "foo"}, {Value: "bar"}}, Params: Params{{Key: "lvl", Value: int64(5)}}},
+				InnerList{Items: []Item{{Value: "baz"}}},
+				InnerList{},
+			},
+		},
+		{
+			Name:  "ItemParams",
+			Input: "abc;a=1;b=2; cde_456, (ghi;jk=4 l);q=\"9\";r=w",
+			Expect: List{
+				Item{Value: Token("abc"), Params: Params{
+					{Key: "a", Value: int64(1)},
+					{Key: "b", Value: int64(2)},
+					{Key: "cde_456", Value: true},
+				}},
+				InnerList{
+					Items: []Item{
+						{Value: Token("ghi"), Params: Params{{Key: "jk", Value: int64(4)}}},
+						{Value: Token("l")},
+					},
+					Params: Params{{Key: "q", Value: "9"}, {Key: "r", Value: Token("w")}},
+				},
+			},
+		},
+
+		{Name: "FailTrailingComma", Input: "a, b,", Fail: true},
+		{Name: "FailEmptyMember", Input: "a,,b", Fail: true},
+		{Name: "FailUnterminated", Input: "(a b", Fail: true},
+		{Name: "FailInnerSeparator", Input: "(a,b)", Fail: true},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, err := ParseList(row.Input)
+			if row.Fail {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", ErrSyntax, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v\n\tactual: %#v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestParseDictionary(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect Dictionary
+		Fail   bool
+	}
+
+	testData := [...]testCase{
+		{Name: "Empty", Input: ""},
+		{
+			Name:  "Mixed",
+			Input: `en="Applepie", da=:w4ZibGV0w6ZydGUK:`,
+			Expect: Dictionary{
+				{Key: "en", Value: Item{Value: "Applepie"}},
+				{Key: "da", Value: Item{Value: ByteSequence("\xc3\x86blet\xc3\xa6rte\n")}},
+			},
+		},
+		{
+			Name:  "Booleans",
+			Input: "a=?0, b, c; foo=bar",
+			Expect: Dictionary{
+				{Key: "a", Value: Item{Value: false}},
+				{Key: "b", Value: Item{Value: true}},
+				{Key: "c", Value: Item{Value: true, Params: Params{{Key: "foo", Value: Token("bar")}}}},
+			},
+		},
+		{
+			Name:  "InnerList",
+			Input: "rating=1.5, feelings=(joy sadness)",
+			Expect: Dictionary{
+				{Key: "rating", Value: Item{Value: Decimal(1500)}},
+				{Key: "feelings", Value: InnerList{Items: []Item{{Value: Token("joy")}, {Value: Token("sadness")}}}},
+			},
+		},
+		{
+			Name:  "Duplicate",
+			Input: "a=1, b=2, a=3",
+			Expect: Dictionary{
+				{Key: "a", Value: Item{Value: int64(3)}},
+				{Key: "b", Value: Item{Value: int64(2)}},
+			},
+		},
+
+		{Name: "FailKey", Input: "A=1", Fail: true},
+		{Name: "FailTrailingComma", Input: "a=1,", Fail: true},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			actual, err := ParseDictionary(row.Input)
+			if row.Fail {
+				if !errors.Is(err, ErrSyntax) {
+					t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", ErrSyntax, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %#v\n\tactual: %#v", row.Expect, actual)
+			}
+		})
+	}
+}
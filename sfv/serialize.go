@@ -0,0 +1,236 @@
+package sfv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+const kDigits = "0123456789"
+
+func (d Decimal) Append(out []byte) []byte {
+	if d < 0 {
+		out = append(out, '-')
+		d = -d
+	}
+	out = strconv.AppendInt(out, int64(d/1000), 10)
+	out = append(out, '.')
+
+	frac := d % 1000
+	a := kDigits[frac/100]
+	b := kDigits[(frac/10)%10]
+	c := kDigits[frac%10]
+	switch {
+	case c != '0':
+		return append(out, a, b, c)
+	case b != '0':
+		return append(out, a, b)
+	default:
+		return append(out, a)
+	}
+}
+
+func (d Decimal) String() string {
+	return string(d.Append(nil))
+}
+
+func (item Item) Append(out []byte) ([]byte, error) {
+	out, err := appendBareItem(out, item.Value)
+	if err != nil {
+		return out, err
+	}
+	return appendParams(out, item.Params)
+}
+
+func (item Item) MarshalText() ([]byte, error) {
+	return item.Append(nil)
+}
+
+func (item Item) appendMember(out []byte) ([]byte, error) {
+	return item.Append(out)
+}
+
+func (list InnerList) appendMember(out []byte) ([]byte, error) {
+	var err error
+	out = append(out, '(')
+	for i, item := range list.Items {
+		if i > 0 {
+			out = append(out, ' ')
+		}
+		out, err = item.Append(out)
+		if err != nil {
+			return out, err
+		}
+	}
+	out = append(out, ')')
+	return appendParams(out, list.Params)
+}
+
+func (list List) Append(out []byte) ([]byte, error) {
+	var err error
+	for i, member := range list {
+		if i > 0 {
+			out = append(out, ", "...)
+		}
+		out, err = appendMember(out, member)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+func (list List) MarshalText() ([]byte, error) {
+	return list.Append(nil)
+}
+
+func (dict Dictionary) Append(out []byte) ([]byte, error) {
+	var err error
+	for i, m := range dict {
+		if i > 0 {
+			out = append(out, ", "...)
+		}
+		out, err = appendKey(out, m.Key)
+		if err != nil {
+			return out, err
+		}
+
+		if item, ok := m.Value.(Item); ok && item.Value == true {
+			out, err = appendParams(out, item.Params)
+		} else {
+			out = append(out, '=')
+			out, err = appendMember(out, m.Value)
+		}
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+func (dict Dictionary) MarshalText() ([]byte, error) {
+	return dict.Append(nil)
+}
+
+func appendMember(out []byte, member Member) ([]byte, error) {
+	if member == nil {
+		return out, fmt.Errorf("%w: nil member", ErrInvalidValue)
+	}
+	return member.appendMember(out)
+}
+
+func appendParams(out []byte, params Params) ([]byte, error) {
+	var err error
+	for _, p := range params {
+		out = append(out, ';')
+		out, err = appendKey(out, p.Key)
+		if err != nil {
+			return out, err
+		}
+		if p.Value == true {
+			continue
+		}
+		out = append(out, '=')
+		out, err = appendBareItem(out, p.Value)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+func appendKey(out []byte, key string) ([]byte, error) {
+	if !isValidKey(key) {
+		return out, fmt.Errorf("%w: invalid key %q", ErrInvalidValue, key)
+	}
+	return append(out, key...), nil
+}
+
+func appendBareItem(out []byte, value any) ([]byte, error) {
+	switch x := value.(type) {
+	case int64:
+		if x < MinInteger || x > MaxInteger {
+			return out, fmt.Errorf("%w: integer %d out of range", ErrInvalidValue, x)
+		}
+		return strconv.AppendInt(out, x, 10), nil
+
+	case int:
+		return appendBareItem(out, int64(x))
+
+	case Decimal:
+		if x < MinDecimal || x > MaxDecimal {
+			return out, fmt.Errorf("%w: decimal %v out of range", ErrInvalidValue, x)
+		}
+		return x.Append(out), nil
+
+	case string:
+		if !isValidString(x) {
+			return out, fmt.Errorf("%w: invalid string %q", ErrInvalidValue, x)
+		}
+		out = append(out, '"')
+		for i := 0; i < len(x); i++ {
+			ch := x[i]
+			if ch == '"' || ch == '\\' {
+				out = append(out, '\\')
+			}
+			out = append(out, ch)
+		}
+		return append(out, '"'), nil
+
+	case Token:
+		if !isValidToken(string(x)) {
+			return out, fmt.Errorf("%w: invalid token %q", ErrInvalidValue, string(x))
+		}
+		return append(out, x...), nil
+
+	case ByteSequence:
+		out = append(out, ':')
+		out = base64.StdEncoding.AppendEncode(out, x)
+		return append(out, ':'), nil
+
+	case []byte:
+		return appendBareItem(out, ByteSequence(x))
+
+	case bool:
+		if x {
+			return append(out, "?1"...), nil
+		}
+		return append(out, "?0"...), nil
+
+	default:
+		return out, fmt.Errorf("%w: unsupported type %T", ErrInvalidValue, value)
+	}
+}
+
+func isValidKey(key string) bool {
+	if key == "" || (!isLCAlpha(key[0]) && key[0] != '*') {
+		return false
+	}
+	for i := 1; i < len(key); i++ {
+		if !isKeyChar(key[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidToken(token string) bool {
+	if token == "" || (!isAlpha(token[0]) && token[0] != '*') {
+		return false
+	}
+	for i := 1; i < len(token); i++ {
+		if !isTokenChar(token[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidString(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if ch := str[i]; ch < 0x20 || ch > 0x7e {
+			return false
+		}
+	}
+	return true
+}
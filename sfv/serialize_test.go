@@ -0,0 +1,103 @@
+package sfv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecimal_String(t *testing.T) {
+	type testCase struct {
+		Input  Decimal
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{0, "0.0"},
+		{1, "0.001"},
+		{10, "0.01"},
+		{500, "0.5"},
+		{1000, "1.0"},
+		{-1250, "-1.25"},
+		{MaxDecimal, "999999999999.999"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Expect, func(t *testing.T) {
+			actual := row.Input.String()
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Kind   string
+		Input  string
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{"Item", "item", `"a\"b";x=1.50;y`, `"a\"b";x=1.5;y`},
+		{"ByteSequence", "item", ":AQID:", ":AQID:"},
+		{"List", "list", "a;q=0.5,\t(b c);d=?0, ?1", "a;q=0.5, (b c);d=?0, ?1"},
+		{"Dictionary", "dict", "a=1, b=?1;x, c=(1 2)", "a=1, b;x, c=(1 2)"},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			var out []byte
+			var err error
+			switch row.Kind {
+			case "item":
+				var x Item
+				if err = x.UnmarshalText([]byte(row.Input)); err == nil {
+					out, err = x.MarshalText()
+				}
+			case "list":
+				var x List
+				if err = x.UnmarshalText([]byte(row.Input)); err == nil {
+					out, err = x.MarshalText()
+				}
+			case "dict":
+				var x Dictionary
+				if err = x.UnmarshalText([]byte(row.Input)); err == nil {
+					out, err = x.MarshalText()
+				}
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := string(out); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestItem_AppendInvalid(t *testing.T) {
+	type testCase struct {
+		Name  string
+		Input Item
+	}
+
+	testData := [...]testCase{
+		{"IntegerRange", Item{Value: int64(MaxInteger + 1)}},
+		{"DecimalRange", Item{Value: MaxDecimal + 1}},
+		{"String", Item{Value: "café"}},
+		{"Token", Item{Value: Token("1abc")}},
+		{"Key", Item{Value: true, Params: Params{{Key: "Upper", Value: true}}}},
+		{"Type", Item{Value: 1.5}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			_, err := row.Input.MarshalText()
+			if !errors.Is(err, ErrInvalidValue) {
+				t.Errorf("wrong error:\n\texpect: %v\n\tactual: %v", ErrInvalidValue, err)
+			}
+		})
+	}
+}
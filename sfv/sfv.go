@@ -0,0 +1,132 @@
+// Package sfv implements Structured Field Values for HTTP (RFC 8941).
+//
+// Bare item values are represented by the Go types int64 (Integer), Decimal,
+// string (String), Token, ByteSequence, and bool (Boolean).
+package sfv
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+)
+
+type Token string
+
+type ByteSequence []byte
+
+// Decimal is a decimal number with three fractional digits, stored as an
+// integer count of thousandths.  For example, 1.5 is Decimal(1500).
+type Decimal int64
+
+const (
+	MaxInteger = 999_999_999_999_999
+	MinInteger = -MaxInteger
+	MaxDecimal = Decimal(999_999_999_999_999)
+	MinDecimal = -MaxDecimal
+)
+
+type Param struct {
+	Key   string
+	Value any
+}
+
+// Params is an ordered map of parameters.
+type Params []Param
+
+func (params Params) Get(key string) (any, bool) {
+	for _, p := range params {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (params *Params) Set(key string, value any) {
+	for i, p := range *params {
+		if p.Key == key {
+			(*params)[i].Value = value
+			return
+		}
+	}
+	*params = append(*params, Param{Key: key, Value: value})
+}
+
+// Member is either an Item or an InnerList.
+type Member interface {
+	appendMember(out []byte) ([]byte, error)
+}
+
+type Item struct {
+	Value  any
+	Params Params
+}
+
+type InnerList struct {
+	Items  []Item
+	Params Params
+}
+
+type List []Member
+
+type DictMember struct {
+	Key   string
+	Value Member
+}
+
+// Dictionary is an ordered map of members.
+type Dictionary []DictMember
+
+func (dict Dictionary) Get(key string) (Member, bool) {
+	for _, m := range dict {
+		if m.Key == key {
+			return m.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (dict *Dictionary) Set(key string, value Member) {
+	for i, m := range *dict {
+		if m.Key == key {
+			(*dict)[i].Value = value
+			return
+		}
+	}
+	*dict = append(*dict, DictMember{Key: key, Value: value})
+}
+
+var (
+	ErrSyntax       = errors.New("invalid structured field syntax")
+	ErrInvalidValue = errors.New("value cannot be serialized as a structured field")
+)
+
+// ParseError describes a syntax error in a structured field value.
+type ParseError struct {
+	Input  string
+	Offset int
+	Msg    string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("%s at offset %d in %q", err.Msg, err.Offset, err.Input)
+}
+
+func (err *ParseError) Unwrap() error {
+	return ErrSyntax
+}
+
+var (
+	_ Member = Item{}
+	_ Member = InnerList{}
+
+	_ fmt.Stringer           = Decimal(0)
+	_ encoding.TextMarshaler = Item{}
+	_ encoding.TextMarshaler = List(nil)
+	_ encoding.TextMarshaler = Dictionary(nil)
+
+	_ encoding.TextUnmarshaler = (*Item)(nil)
+	_ encoding.TextUnmarshaler = (*List)(nil)
+	_ encoding.TextUnmarshaler = (*Dictionary)(nil)
+	_ error                    = (*ParseError)(nil)
+)
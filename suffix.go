@@ -0,0 +1,32 @@
+package acceptable
+
+import (
+	"strings"
+)
+
+// SplitSuffix splits a media subtype into its base and its structured syntax
+// suffix (RFC 6838 section 4.2.8), such as "vnd.foo" and "json" for
+// "vnd.foo+json".  If subValue has no suffix, suffix is empty.
+func SplitSuffix(subValue string) (base, suffix string) {
+	i := strings.LastIndexByte(subValue, '+')
+	if i <= 0 || i == len(subValue)-1 {
+		return subValue, ""
+	}
+	return subValue[:i], subValue[i+1:]
+}
+
+// isMatchingSuffix reports whether actual and pattern match by way of a
+// structured syntax suffix: "vnd.foo+json" matches "json", and "json"
+// matches "problem+json" or "*+json".
+func isMatchingSuffix(actual, pattern string) bool {
+	_, as := SplitSuffix(actual)
+	_, ps := SplitSuffix(pattern)
+	switch {
+	case as != "" && ps == "":
+		return strings.EqualFold(as, pattern)
+	case as == "" && ps != "":
+		return strings.EqualFold(ps, actual)
+	default:
+		return false
+	}
+}
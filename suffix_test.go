@@ -0,0 +1,212 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSuffix(t *testing.T) {
+	type testCase struct {
+		Input        string
+		ExpectBase   string
+		ExpectSuffix string
+	}
+
+	testData := [...]testCase{
+		{"json", "json", ""},
+		{"problem+json", "problem", "json"},
+		{"vnd.acme.order+json", "vnd.acme.order", "json"},
+		{"vnd.a+b+cbor", "vnd.a+b", "cbor"},
+		{"*+xml", "*", "xml"},
+		{"+json", "+json", ""},
+		{"json+", "json+", ""},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Input, func(t *testing.T) {
+			base, suffix := SplitSuffix(row.Input)
+			if base != row.ExpectBase || suffix != row.ExpectSuffix {
+				t.Errorf("wrong result:\n\texpect: %q, %q\n\tactual: %q, %q", row.ExpectBase, row.ExpectSuffix, base, suffix)
+			}
+		})
+	}
+}
+
+func TestNegotiator_SuffixQuality(t *testing.T) {
+	type testCase struct {
+		Name        string
+		Negotiator  Negotiator
+		Available   List
+		Preferences List
+		Expect      Result
+	}
+
+	suffix := Negotiator{SuffixQuality: 500}
+	json := Acceptable{"application", "json", nil, 1000, nil}
+	order := Acceptable{"application", "vnd.acme.order+json", nil, 1000, nil}
+
+	testData := [...]testCase{
+		{
+			Name:        "Disabled",
+			Available:   List{order},
+			Preferences: List{json},
+			Expect:      Result{Offer: order, PreferenceIndex: -1},
+		},
+		{
+			Name:        "OfferSuffix",
+			Negotiator:  suffix,
+			Available:   List{order},
+			Preferences: List{json},
+			Expect: Result{
+				Offer:       order,
+				Preference:  json,
+				Score:       0.5,
+				Matched:     true,
+				SuffixMatch: true,
+				Acceptable:  true,
+			},
+		},
+		{
+			Name:        "PreferenceSuffix",
+			Negotiator:  suffix,
+			Available:   List{json},
+			Preferences: List{{"application", "problem+json", nil, 1000, nil}},
+			Expect: Result{
+				Offer:       json,
+				Preference:  Acceptable{"application", "problem+json", nil, 1000, nil},
+				Score:       0.5,
+				Matched:     true,
+				SuffixMatch: true,
+				Acceptable:  true,
+			},
+		},
+		{
+			Name:        "StarSuffix",
+			Available:   List{order},
+			Preferences: List{{"application", "*+json", nil, 1000, nil}},
+			Expect: Result{
+				Offer:      order,
+				Preference: Acceptable{"application", "*+json", nil, 1000, nil},
+				Score:      1,
+				Matched:    true,
+				Acceptable: true,
+			},
+		},
+		{
+			Name:        "StarSuffixPlain",
+			Negotiator:  suffix,
+			Available:   List{json},
+			Preferences: List{{"application", "*+json", nil, 1000, nil}},
+			Expect: Result{
+				Offer:       json,
+				Preference:  Acceptable{"application", "*+json", nil, 1000, nil},
+				Score:       0.5,
+				Matched:     true,
+				SuffixMatch: true,
+				Acceptable:  true,
+			},
+		},
+		{
+			Name:        "ExactBeatsSuffix",
+			Negotiator:  suffix,
+			Available:   List{order, json},
+			Preferences: List{json},
+			Expect: Result{
+				Offer:      json,
+				Preference: json,
+				Index:      1,
+				Score:      1,
+				Matched:    true,
+				Acceptable: true,
+			},
+		},
+		{
+			Name:       "ExactBeatsEquallySpecificSuffix",
+			Negotiator: suffix,
+			Available:  List{order},
+			Preferences: List{
+				json,
+				{"application", "vnd.acme.order+json", nil, 800, nil},
+			},
+			Expect: Result{
+				Offer:           order,
+				Preference:      Acceptable{"application", "vnd.acme.order+json", nil, 800, nil},
+				PreferenceIndex: 1,
+				Score:           0.8,
+				Matched:         true,
+				Acceptable:      true,
+			},
+		},
+		{
+			Name:       "ExactBeatsMoreSpecificSuffix",
+			Negotiator: suffix,
+			Available:  List{order},
+			Preferences: List{
+				json,
+				{"*", "*", nil, 100, nil},
+			},
+			Expect: Result{
+				Offer:           order,
+				Preference:      Acceptable{"*", "*", nil, 100, nil},
+				PreferenceIndex: 1,
+				Score:           0.1,
+				Matched:         true,
+				Acceptable:      true,
+			},
+		},
+		{
+			Name:       "ExactWildcardBeatsPreferenceSuffix",
+			Negotiator: suffix,
+			Available:  List{json},
+			Preferences: List{
+				{"application", "problem+json", nil, 1000, nil},
+				{"application", "*", nil, 1000, nil},
+			},
+			Expect: Result{
+				Offer:           json,
+				Preference:      Acceptable{"application", "*", nil, 1000, nil},
+				PreferenceIndex: 1,
+				Score:           1,
+				Matched:         true,
+				Acceptable:      true,
+			},
+		},
+		{
+			Name:       "ZeroWeightSuffixSkipped",
+			Negotiator: suffix,
+			Available:  List{json},
+			Preferences: List{
+				{"application", "problem+json", nil, 0, nil},
+				{"application", "*+json", nil, 500, nil},
+			},
+			Expect: Result{
+				Offer:           json,
+				Preference:      Acceptable{"application", "*+json", nil, 500, nil},
+				PreferenceIndex: 1,
+				Score:           0.25,
+				Matched:         true,
+				SuffixMatch:     true,
+				Acceptable:      true,
+			},
+		},
+		{
+			Name:        "ZeroWeightSuffixOnly",
+			Negotiator:  suffix,
+			Available:   List{order},
+			Preferences: List{{"application", "json", nil, 0, nil}},
+			Expect:      Result{Offer: order, PreferenceIndex: -1},
+		},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			results := row.Negotiator.NegotiateAll(row.Available, row.Preferences)
+			if len(results) <= 0 {
+				t.Fatalf("no results")
+			}
+			if actual := results[0]; !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %+v\n\tactual: %+v", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package acceptable
+
+// TieBreak orders two results with equal scores, returning a negative
+// number if a should rank ahead of b, a positive number if b should rank
+// ahead of a, or zero if either order will do.  Results that remain tied
+// keep the order in which the server listed the offers.
+type TieBreak func(a, b Result) int
+
+var (
+	// LexicalTieBreak prefers offers in canonical order (see
+	// Acceptable.CompareTo).  This is the default.
+	LexicalTieBreak TieBreak = compareLexical
+
+	// ServerOrderTieBreak prefers offers in the order the server listed
+	// them.
+	ServerOrderTieBreak TieBreak = compareServerOrder
+
+	// ClientOrderTieBreak prefers offers whose matching preference the
+	// client listed first.  Unmatched offers rank last.
+	ClientOrderTieBreak TieBreak = compareClientOrder
+)
+
+func compareLexical(a, b Result) int {
+	return a.Offer.CompareTo(b.Offer)
+}
+
+func compareServerOrder(a, b Result) int {
+	return compareUints(uint(a.Index), uint(b.Index))
+}
+
+func compareClientOrder(a, b Result) int {
+	// An unmatched PreferenceIndex of -1 converts to the largest uint.
+	return compareUints(uint(a.PreferenceIndex), uint(b.PreferenceIndex))
+}
@@ -0,0 +1,63 @@
+package acceptable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegotiator_TieBreak(t *testing.T) {
+	type testCase struct {
+		Name     string
+		TieBreak TieBreak
+		Expect   []int
+	}
+
+	available := List{
+		{"text", "html", nil, 1000, nil},
+		{"application", "json", nil, 1000, nil},
+		{"image", "png", nil, 1000, nil},
+		{"font", "woff", nil, 1000, nil},
+	}
+	preferences := List{
+		{"image", "png", nil, 1000, nil},
+		{"text", "html", nil, 1000, nil},
+		{"application", "json", nil, 1000, nil},
+	}
+
+	testData := [...]testCase{
+		{"Default", nil, []int{1, 2, 0, 3}},
+		{"Lexical", LexicalTieBreak, []int{1, 2, 0, 3}},
+		{"ServerOrder", ServerOrderTieBreak, []int{0, 1, 2, 3}},
+		{"ClientOrder", ClientOrderTieBreak, []int{2, 0, 1, 3}},
+		{"Custom", func(a, b Result) int { return -a.Offer.CompareTo(b.Offer) }, []int{0, 2, 1, 3}},
+	}
+
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			n := Negotiator{TieBreak: row.TieBreak}
+			results := n.NegotiateAll(available, preferences)
+			actual := make([]int, len(results))
+			for i, r := range results {
+				actual[i] = r.Index
+			}
+			if !reflect.DeepEqual(actual, row.Expect) {
+				t.Errorf("wrong result:\n\texpect: %v\n\tactual: %v", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestNegotiator_TieBreakStable(t *testing.T) {
+	available := List{
+		{"text", "html", nil, 1000, nil},
+		{"text", "html", nil, 1000, nil},
+		{"text", "html", nil, 1000, nil},
+	}
+
+	results := NegotiateAll(available, List{{"*", "*", nil, 1000, nil}})
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("wrong order at %d: index %d", i, r.Index)
+		}
+	}
+}